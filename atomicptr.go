@@ -0,0 +1,139 @@
+package wrap
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+)
+
+// AtomicPtr is a concurrency-safe variant of Ptr, backed by an atomic pointer of type T.
+// Values are never mutated in place: every write stores a new pointer, so readers always see a consistent value.
+// The zero value is a nil pointer ready to use. An AtomicPtr must not be copied after first use.
+//
+// Since it cannot be copied, the codecs of AtomicPtr have pointer receivers: a struct holding an AtomicPtr field
+// must be marshalled through a pointer, such as json.Marshal(&config), otherwise the field encodes as empty.
+type AtomicPtr[T any] struct {
+	p atomic.Pointer[T]
+}
+
+// NewAtomicPtr creates a new AtomicPtr instance with the provided pointer to T.
+func NewAtomicPtr[T any](ptr *T) *AtomicPtr[T] {
+	p := &AtomicPtr[T]{}
+	p.p.Store(ptr)
+	return p
+}
+
+// NewNilAtomicPtr creates a new AtomicPtr instance of type T with no value.
+func NewNilAtomicPtr[T any]() *AtomicPtr[T] {
+	return &AtomicPtr[T]{}
+}
+
+// Unwrap returns the underlying pointer of type T.
+func (p *AtomicPtr[T]) Unwrap() *T {
+	return p.p.Load()
+}
+
+// Load atomically loads and returns the underlying pointer.
+func (p *AtomicPtr[T]) Load() *T {
+	return p.p.Load()
+}
+
+// Store atomically stores the provided pointer.
+func (p *AtomicPtr[T]) Store(ptr *T) {
+	p.p.Store(ptr)
+}
+
+// Swap atomically stores the new pointer and returns the previous one.
+func (p *AtomicPtr[T]) Swap(new *T) *T {
+	return p.p.Swap(new)
+}
+
+// CompareAndSwap atomically swaps the pointer to new if it currently equals old.
+func (p *AtomicPtr[T]) CompareAndSwap(old, new *T) bool {
+	return p.p.CompareAndSwap(old, new)
+}
+
+// GetValue returns the value pointed to by the AtomicPtr and a boolean indicating if the pointer is non-nil.
+func (p *AtomicPtr[T]) GetValue() (T, bool) {
+	var zero T
+	ptr := p.p.Load()
+	if ptr == nil {
+		return zero, false
+	}
+	return *ptr, true
+}
+
+// SetValue atomically stores a new pointer to a copy of the value.
+func (p *AtomicPtr[T]) SetValue(value T) {
+	p.p.Store(&value)
+}
+
+// Update atomically replaces the value with the result of the provided function, retrying if another goroutine
+// changed the pointer in the meantime. The function may therefore run more than once and should be free of side effects.
+// It returns the stored value.
+func (p *AtomicPtr[T]) Update(update func(old T, ok bool) T) T {
+	for {
+		current := p.p.Load()
+		var old T
+		if current != nil {
+			old = *current
+		}
+		value := update(old, current != nil)
+		if p.p.CompareAndSwap(current, &value) {
+			return value
+		}
+	}
+}
+
+// Clear atomically sets the pointer to nil, effectively clearing its value.
+func (p *AtomicPtr[T]) Clear() {
+	p.p.Store(nil)
+}
+
+// IsNil returns true if the pointer is nil, otherwise false.
+func (p *AtomicPtr[T]) IsNil() bool {
+	return p.p.Load() == nil
+}
+
+// UnmarshalJSON unmarshals JSON data into the AtomicPtr. It handles both "null" and non-null values.
+func (p *AtomicPtr[T]) UnmarshalJSON(data []byte) error {
+	var decoded Ptr[T]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	p.p.Store(decoded.X)
+	return nil
+}
+
+// MarshalJSON marshals the AtomicPtr into JSON. If the pointer is nil, it serializes as "null".
+// It is only called for addressable values, see AtomicPtr.
+func (p *AtomicPtr[T]) MarshalJSON() ([]byte, error) {
+	return NewPtr(p.p.Load()).MarshalJSON()
+}
+
+// UnmarshalXML unmarshals XML data into the AtomicPtr. It handles character data and XML elements.
+func (p *AtomicPtr[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var decoded Ptr[T]
+	if err := decoded.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	p.p.Store(decoded.X)
+	return nil
+}
+
+// MarshalXML marshals the AtomicPtr into XML. If the pointer is nil, it serializes as a nil element.
+// It is only called for addressable values, see AtomicPtr.
+func (p *AtomicPtr[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return NewPtr(p.p.Load()).MarshalXML(e, start)
+}
+
+// String returns a string representation of the AtomicPtr, using the default formatting for its value.
+func (p *AtomicPtr[T]) String() string {
+	return fmt.Sprintf("%v", p.p.Load())
+}
+
+// cloneReflect returns a deep copy of the AtomicPtr for DeepCopy, pointing to a copy of the currently loaded value.
+func (p *AtomicPtr[T]) cloneReflect(c *cloneState) reflect.Value {
+	return reflect.ValueOf(NewAtomicPtr(cloneValue(c, p.p.Load()))).Elem()
+}
@@ -0,0 +1,122 @@
+package wrap_test
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"sync"
+	"testing"
+
+	"github.com/twoojoo/wrap"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAtomicPtr_GetSetClear(t *testing.T) {
+	ptr := wrap.NewNilAtomicPtr[int]()
+	assert.True(t, ptr.IsNil())
+
+	_, ok := ptr.GetValue()
+	assert.False(t, ok)
+
+	ptr.SetValue(42)
+	val, ok := ptr.GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, 42, val)
+
+	ptr.Clear()
+	assert.True(t, ptr.IsNil())
+	assert.Nil(t, ptr.Unwrap())
+}
+
+func TestAtomicPtr_SetValueDoesNotMutatePointee(t *testing.T) {
+	value := 1
+	ptr := wrap.NewAtomicPtr(&value)
+	old := ptr.Load()
+
+	ptr.SetValue(2)
+	assert.Equal(t, 1, *old)
+	assert.Equal(t, 2, *ptr.Load())
+}
+
+func TestAtomicPtr_SwapAndCompareAndSwap(t *testing.T) {
+	a, b, c := 1, 2, 3
+	ptr := wrap.NewAtomicPtr(&a)
+
+	old := ptr.Swap(&b)
+	assert.Equal(t, &a, old)
+
+	assert.False(t, ptr.CompareAndSwap(&a, &c))
+	assert.True(t, ptr.CompareAndSwap(&b, &c))
+	assert.Equal(t, &c, ptr.Load())
+
+	ptr.Store(nil)
+	assert.True(t, ptr.IsNil())
+}
+
+func TestAtomicPtr_Update(t *testing.T) {
+	var ptr wrap.AtomicPtr[int]
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ptr.Update(func(old int, ok bool) int { return old + 1 })
+		}()
+	}
+	wg.Wait()
+
+	val, ok := ptr.GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, 100, val)
+}
+
+func TestAtomicPtr_JSON(t *testing.T) {
+	type Config struct {
+		Limit wrap.AtomicPtr[int]    `json:"limit"`
+		Name  wrap.AtomicPtr[string] `json:"name"`
+	}
+
+	config := &Config{}
+	config.Limit.SetValue(42)
+	data, err := json.Marshal(config)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"limit":42,"name":null}`, string(data))
+
+	var decoded Config
+	decoded.Limit.SetValue(1)
+	err = json.Unmarshal([]byte(`{"limit":null,"name":"test"}`), &decoded)
+	assert.NoError(t, err)
+	assert.True(t, decoded.Limit.IsNil())
+	name, ok := decoded.Name.GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, "test", name)
+}
+
+func TestAtomicPtr_XML(t *testing.T) {
+	type Test struct {
+		Value wrap.AtomicPtr[int] `xml:"value"`
+	}
+
+	testStruct := &Test{}
+	testStruct.Value.SetValue(42)
+	data, err := xml.Marshal(testStruct)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), "<value>42</value>")
+
+	testStruct.Value.Clear()
+	data, err = xml.Marshal(testStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, "<Test></Test>", string(data))
+
+	var decoded Test
+	err = xml.Unmarshal([]byte("<Test><value>42</value></Test>"), &decoded)
+	assert.NoError(t, err)
+	val, ok := decoded.Value.GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, 42, val)
+
+	err = xml.Unmarshal([]byte("<Test><value></value></Test>"), &decoded)
+	assert.NoError(t, err)
+	assert.True(t, decoded.Value.IsNil())
+}
@@ -0,0 +1,199 @@
+package wrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// ErrBiMapConflict is returned when a BiMap value is already mapped to a different key.
+var ErrBiMapConflict = errors.New("wrap: value is already mapped to a different key")
+
+// BiMap is a generic bidirectional map keeping a one-to-one mapping between keys of type K and values of type V,
+// so values can be looked up by key and keys by value.
+// The zero value is an empty map ready to use.
+type BiMap[K comparable, V comparable] struct {
+	forward map[K]V
+	inverse map[V]K
+}
+
+// NewBiMap creates a new empty BiMap instance.
+func NewBiMap[K comparable, V comparable]() *BiMap[K, V] {
+	return &BiMap[K, V]{
+		forward: make(map[K]V),
+		inverse: make(map[V]K),
+	}
+}
+
+// BiMapFromMap creates a new BiMap instance holding the key-value pairs of the provided Map.
+// It returns an error wrapping ErrBiMapConflict if two keys share the same value.
+func BiMapFromMap[K comparable, V comparable](m Map[K, V]) (*BiMap[K, V], error) {
+	b := NewBiMap[K, V]()
+	for key, value := range m.X {
+		if err := b.TrySet(key, value); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// init lazily allocates the underlying maps.
+func (b *BiMap[K, V]) init() {
+	if b.forward == nil {
+		b.forward = make(map[K]V)
+		b.inverse = make(map[V]K)
+	}
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (b *BiMap[K, V]) Get(key K) (V, bool) {
+	value, exists := b.forward[key]
+	return value, exists
+}
+
+// GetKey retrieves the key associated with the specified value and a boolean indicating if the value exists.
+func (b *BiMap[K, V]) GetKey(value V) (K, bool) {
+	key, exists := b.inverse[value]
+	return key, exists
+}
+
+// Set associates the key with the value and returns the BiMap instance.
+// Any existing pair holding the key or the value is removed first, so the mapping stays one-to-one.
+func (b *BiMap[K, V]) Set(key K, value V) *BiMap[K, V] {
+	b.init()
+	b.Delete(key)
+	b.DeleteValue(value)
+	b.forward[key] = value
+	b.inverse[value] = key
+	return b
+}
+
+// TrySet associates the key with the value, replacing the previous value of the key if any.
+// It returns an error wrapping ErrBiMapConflict, leaving the map unchanged, if the value is already mapped to a different key.
+func (b *BiMap[K, V]) TrySet(key K, value V) error {
+	if existing, exists := b.inverse[value]; exists && existing != key {
+		return fmt.Errorf("%w: %v", ErrBiMapConflict, value)
+	}
+	b.Set(key, value)
+	return nil
+}
+
+// Delete removes the pair holding the specified key and returns the BiMap instance.
+func (b *BiMap[K, V]) Delete(key K) *BiMap[K, V] {
+	if value, exists := b.forward[key]; exists {
+		delete(b.forward, key)
+		delete(b.inverse, value)
+	}
+	return b
+}
+
+// DeleteValue removes the pair holding the specified value and returns the BiMap instance.
+func (b *BiMap[K, V]) DeleteValue(value V) *BiMap[K, V] {
+	if key, exists := b.inverse[value]; exists {
+		delete(b.inverse, value)
+		delete(b.forward, key)
+	}
+	return b
+}
+
+// Contains checks if the specified key exists in the map.
+func (b *BiMap[K, V]) Contains(key K) bool {
+	_, exists := b.forward[key]
+	return exists
+}
+
+// ContainsValue checks if the specified value exists in the map.
+func (b *BiMap[K, V]) ContainsValue(value V) bool {
+	_, exists := b.inverse[value]
+	return exists
+}
+
+// Keys returns a slice of all keys in the map.
+func (b *BiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(b.forward))
+	for key := range b.forward {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns a slice of all values in the map.
+func (b *BiMap[K, V]) Values() Slice[V] {
+	values := make([]V, 0, len(b.inverse))
+	for value := range b.inverse {
+		values = append(values, value)
+	}
+	return NewSlice(values)
+}
+
+// Len returns the number of key-value pairs in the map.
+func (b *BiMap[K, V]) Len() int {
+	return len(b.forward)
+}
+
+// IsEmpty returns true if the map is empty, otherwise false.
+func (b *BiMap[K, V]) IsEmpty() bool {
+	return len(b.forward) == 0
+}
+
+// Clear removes all key-value pairs from the map. The change is visible through any Inverse view.
+func (b *BiMap[K, V]) Clear() {
+	for key := range b.forward {
+		delete(b.forward, key)
+	}
+	for value := range b.inverse {
+		delete(b.inverse, value)
+	}
+}
+
+// Inverse returns a view of the map with keys and values swapped. The view shares storage with the map,
+// so it costs nothing to create and changes made through either are visible in both.
+func (b *BiMap[K, V]) Inverse() *BiMap[V, K] {
+	b.init()
+	return &BiMap[V, K]{
+		forward: b.inverse,
+		inverse: b.forward,
+	}
+}
+
+// ToMap returns a new Map holding the key-value pairs of the map.
+func (b *BiMap[K, V]) ToMap() Map[K, V] {
+	copied := make(map[K]V, len(b.forward))
+	for key, value := range b.forward {
+		copied[key] = value
+	}
+	return NewMap(copied)
+}
+
+// UnmarshalJSON unmarshals a JSON object into the BiMap.
+// It returns an error wrapping ErrBiMapConflict if two keys share the same value.
+func (b *BiMap[K, V]) UnmarshalJSON(data []byte) error {
+	var decoded Map[K, V]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	parsed, err := BiMapFromMap(decoded)
+	if err != nil {
+		return err
+	}
+	b.init()
+	b.Clear()
+	for key, value := range parsed.forward {
+		b.forward[key] = value
+		b.inverse[value] = key
+	}
+	return nil
+}
+
+// MarshalJSON marshals the BiMap into JSON. It produces a JSON object representation from keys to values.
+func (b BiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(b.forward)
+}
+
+// cloneReflect returns a copy of the BiMap for DeepCopy. Keys and values are both map keys, so like the keys of
+// any map they are copied as is.
+func (b *BiMap[K, V]) cloneReflect(*cloneState) reflect.Value {
+	return reflect.ValueOf(BiMap[K, V]{forward: maps.Clone(b.forward), inverse: maps.Clone(b.inverse)})
+}
@@ -0,0 +1,134 @@
+package wrap
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBiMap_SetAndGet(t *testing.T) {
+	var b BiMap[string, int]
+	b.Set("one", 1).Set("two", 2)
+
+	value, ok := b.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	key, ok := b.GetKey(2)
+	assert.True(t, ok)
+	assert.Equal(t, "two", key)
+
+	_, ok = b.GetKey(3)
+	assert.False(t, ok)
+	assert.Equal(t, 2, b.Len())
+	assert.ElementsMatch(t, []string{"one", "two"}, b.Keys())
+	values := b.Values()
+	assert.ElementsMatch(t, []int{1, 2}, values.Unwrap())
+}
+
+func TestBiMap_SetOverwrites(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		value    int
+		expected map[string]int
+	}{
+		{"NewPair", "c", 3, map[string]int{"a": 1, "b": 2, "c": 3}},
+		{"ExistingKey", "a", 3, map[string]int{"a": 3, "b": 2}},
+		{"ExistingValue", "c", 1, map[string]int{"b": 2, "c": 1}},
+		{"BothExisting", "a", 2, map[string]int{"a": 2}},
+		{"SamePair", "a", 1, map[string]int{"a": 1, "b": 2}},
+	}
+
+	for _, tt := range tests {
+		b := NewBiMap[string, int]()
+		b.Set("a", 1).Set("b", 2)
+		b.Set(tt.key, tt.value)
+
+		m := b.ToMap()
+		assert.Equal(t, tt.expected, m.Unwrap(), tt.name)
+		assert.Equal(t, len(tt.expected), b.Inverse().Len(), "%s: inverse should stay in sync", tt.name)
+	}
+}
+
+func TestBiMap_TrySet(t *testing.T) {
+	b := NewBiMap[string, int]()
+	assert.NoError(t, b.TrySet("a", 1))
+	assert.NoError(t, b.TrySet("a", 1), "Setting the same pair again is not a conflict")
+	assert.NoError(t, b.TrySet("a", 2), "Replacing the value of a key is not a conflict")
+
+	err := b.TrySet("b", 2)
+	assert.True(t, errors.Is(err, ErrBiMapConflict))
+	assert.False(t, b.Contains("b"), "A conflict should leave the map unchanged")
+	assert.False(t, b.ContainsValue(1))
+}
+
+func TestBiMap_Delete(t *testing.T) {
+	b := NewBiMap[string, int]()
+	b.Set("a", 1).Set("b", 2).Set("c", 3)
+
+	b.Delete("a").DeleteValue(2).Delete("missing")
+	assert.Equal(t, map[string]int{"c": 3}, b.ToMap().X)
+	assert.False(t, b.ContainsValue(1))
+	assert.False(t, b.Contains("b"))
+
+	b.Clear()
+	assert.True(t, b.IsEmpty())
+}
+
+func TestBiMap_Inverse(t *testing.T) {
+	var b BiMap[string, int]
+	inverse := b.Inverse()
+
+	inverse.Set(1, "one")
+	value, ok := b.Get("one")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value, "Changes through the inverse should be visible in the map")
+
+	b.Set("two", 2)
+	key, ok := inverse.Get(2)
+	assert.True(t, ok)
+	assert.Equal(t, "two", key, "Changes to the map should be visible in the inverse")
+
+	b.Clear()
+	assert.True(t, inverse.IsEmpty())
+}
+
+func TestBiMap_FromMap(t *testing.T) {
+	b, err := BiMapFromMap(NewMap(map[string]int{"a": 1, "b": 2}))
+	assert.NoError(t, err)
+	key, _ := b.GetKey(2)
+	assert.Equal(t, "b", key)
+
+	_, err = BiMapFromMap(NewMap(map[string]int{"a": 1, "b": 1}))
+	assert.True(t, errors.Is(err, ErrBiMapConflict))
+}
+
+func TestBiMap_JSON(t *testing.T) {
+	b := NewBiMap[string, int]()
+	b.Set("a", 1).Set("b", 2)
+	data, err := json.Marshal(b)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+
+	var decoded BiMap[string, int]
+	err = json.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	key, _ := decoded.GetKey(1)
+	assert.Equal(t, "a", key)
+
+	err = json.Unmarshal([]byte(`{"a":1,"b":1}`), &decoded)
+	assert.True(t, errors.Is(err, ErrBiMapConflict), "Duplicate values should be rejected")
+	assert.Equal(t, 2, decoded.Len(), "A rejected document should leave the map unchanged")
+
+	type Test struct {
+		Codes BiMap[string, int] `json:"codes"`
+	}
+	var test Test
+	test.Codes.Set("a", 1)
+	data, err = json.Marshal(test)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"codes":{"a":1}}`, string(data), "By-value fields should keep their data")
+}
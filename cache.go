@@ -0,0 +1,260 @@
+package wrap
+
+import (
+	"reflect"
+	"sync"
+	"time"
+)
+
+// Cache is a concurrency-safe, size-bound cache with keys of type K and values of type V using
+// a least recently used (LRU) eviction policy. The capacity bounds the number of entries, or their
+// total cost when a weigher is configured with WithWeigher.
+type Cache[K comparable, V any] struct {
+	mu       sync.Mutex
+	entries  *OrderedMap[K, cacheEntry[V]]
+	capacity int64
+	cost     int64
+	weigh    func(V) int64
+	onEvict  func(K, V)
+	clock    Clock
+	stats    CacheStats
+}
+
+// cacheEntry is a value stored in a Cache along with its cost and last access time.
+type cacheEntry[V any] struct {
+	value    V
+	cost     int64
+	accessed time.Time
+}
+
+// CacheStats holds the hit, miss and eviction counters of a Cache.
+type CacheStats struct {
+	Hits      uint64
+	Misses    uint64
+	Evictions uint64
+}
+
+// CacheOption configures a Cache created with NewCache.
+type CacheOption[K comparable, V any] func(*Cache[K, V])
+
+// WithEvictionCallback sets a function called with every entry the Cache evicts.
+// It runs after the cache lock is released, so it may call back into the Cache.
+func WithEvictionCallback[K comparable, V any](onEvict func(K, V)) CacheOption[K, V] {
+	return func(c *Cache[K, V]) {
+		c.onEvict = onEvict
+	}
+}
+
+// WithWeigher makes the Cache capacity bound the total cost of the entries, as computed by weigh, instead of their number.
+// Set rejects values costing more than the whole capacity.
+func WithWeigher[K comparable, V any](weigh func(V) int64) CacheOption[K, V] {
+	return func(c *Cache[K, V]) {
+		c.weigh = weigh
+	}
+}
+
+// WithCacheClock sets the Clock used to record access times. It defaults to the system clock.
+func WithCacheClock[K comparable, V any](clock Clock) CacheOption[K, V] {
+	return func(c *Cache[K, V]) {
+		c.clock = clock
+	}
+}
+
+// NewCache creates a new empty Cache instance holding at most capacity entries, or capacity total cost with WithWeigher.
+func NewCache[K comparable, V any](capacity int64, options ...CacheOption[K, V]) *Cache[K, V] {
+	c := &Cache[K, V]{
+		entries:  NewOrderedMap[K, cacheEntry[V]](),
+		capacity: capacity,
+		clock:    systemClock{},
+	}
+	for _, option := range options {
+		option(c)
+	}
+	return c
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+// A hit marks the entry as the most recently used.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		c.stats.Misses++
+		var zero V
+		return zero, false
+	}
+	c.stats.Hits++
+	entry.accessed = c.clock.Now()
+	c.entries.Set(key, entry)
+	c.entries.MoveToFront(key)
+	return entry.value, true
+}
+
+// Peek retrieves the value associated with the specified key without marking it as used or updating the counters.
+func (c *Cache[K, V]) Peek(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.Get(key)
+	return entry.value, ok
+}
+
+// Contains returns true if the key is cached, without marking it as used or updating the counters.
+func (c *Cache[K, V]) Contains(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Contains(key)
+}
+
+// Set adds or updates the value for the specified key, marks it as the most recently used and
+// evicts least recently used entries until the cache fits its capacity. A value costing more than the
+// whole capacity is rejected: Set returns false and leaves the cache unchanged, including any previous
+// value for the key.
+func (c *Cache[K, V]) Set(key K, value V) bool {
+	cost := c.costOf(value)
+	if cost > c.capacity {
+		return false
+	}
+
+	c.mu.Lock()
+	if previous, ok := c.entries.Get(key); ok {
+		c.cost -= previous.cost
+	}
+	entry := cacheEntry[V]{value: value, cost: cost, accessed: c.clock.Now()}
+	c.cost += entry.cost
+	c.entries.Set(key, entry)
+	c.entries.MoveToFront(key)
+
+	var evicted []MapEntry[K, V]
+	for c.cost > c.capacity {
+		last, ok := c.entries.Last()
+		if !ok {
+			break
+		}
+		evicted = append(evicted, c.evict(last))
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return true
+}
+
+// Delete removes the entry associated with the specified key and returns whether it existed.
+// Deleted entries are not reported to the eviction callback.
+func (c *Cache[K, V]) Delete(key K) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries.Get(key)
+	if !ok {
+		return false
+	}
+	c.cost -= entry.cost
+	c.entries.Delete(key)
+	return true
+}
+
+// EvictIdle evicts the entries that have not been used within the specified duration and returns how many were evicted.
+func (c *Cache[K, V]) EvictIdle(idle time.Duration) int {
+	c.mu.Lock()
+	cutoff := c.clock.Now().Add(-idle)
+	var evicted []MapEntry[K, V]
+	for {
+		last, ok := c.entries.Last()
+		if !ok || !last.Value.accessed.Before(cutoff) {
+			break
+		}
+		evicted = append(evicted, c.evict(last))
+	}
+	c.mu.Unlock()
+
+	c.notify(evicted)
+	return len(evicted)
+}
+
+// Len returns the number of entries in the cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// Cost returns the total cost of the entries in the cache, which is their number when no weigher is configured.
+func (c *Cache[K, V]) Cost() int64 {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.cost
+}
+
+// Keys returns a slice of all keys in the cache, from the most to the least recently used.
+func (c *Cache[K, V]) Keys() []K {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Keys()
+}
+
+// Stats returns a snapshot of the hit, miss and eviction counters.
+func (c *Cache[K, V]) Stats() CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.stats
+}
+
+// Clear removes all entries from the cache without reporting them to the eviction callback. The counters are kept.
+func (c *Cache[K, V]) Clear() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries.Clear()
+	c.cost = 0
+}
+
+// costOf returns the cost of a value.
+func (c *Cache[K, V]) costOf(value V) int64 {
+	if c.weigh == nil {
+		return 1
+	}
+	return c.weigh(value)
+}
+
+// evict removes an entry and counts the eviction. The caller must hold the lock.
+func (c *Cache[K, V]) evict(entry MapEntry[K, cacheEntry[V]]) MapEntry[K, V] {
+	c.entries.Delete(entry.Key)
+	c.cost -= entry.Value.cost
+	c.stats.Evictions++
+	return MapEntry[K, V]{Key: entry.Key, Value: entry.Value.value}
+}
+
+// notify reports evicted entries to the eviction callback. The caller must not hold the lock.
+func (c *Cache[K, V]) notify(evicted []MapEntry[K, V]) {
+	if c.onEvict == nil {
+		return
+	}
+	for _, entry := range evicted {
+		c.onEvict(entry.Key, entry.Value)
+	}
+}
+
+// cloneReflect returns a deep copy of the Cache for DeepCopy, with the same entries, recency order, configuration
+// and stats.
+func (c *Cache[K, V]) cloneReflect(state *cloneState) reflect.Value {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	copied := &Cache[K, V]{
+		capacity: c.capacity,
+		cost:     c.cost,
+		weigh:    c.weigh,
+		onEvict:  c.onEvict,
+		clock:    c.clock,
+		stats:    c.stats,
+	}
+	if c.entries != nil {
+		copied.entries = NewOrderedMap[K, cacheEntry[V]]()
+		for key, entry := range c.entries.Iter() {
+			entry.value = cloneValue(state, entry.value)
+			copied.entries.Set(key, entry)
+		}
+	}
+	return reflect.ValueOf(copied).Elem()
+}
@@ -0,0 +1,211 @@
+package wrap
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeClock is a Clock whose time only moves when advanced. Its timers fire when Advance reaches their deadline.
+type fakeClock struct {
+	mu      sync.Mutex
+	cond    *sync.Cond
+	now     time.Time
+	waiters []fakeTimer
+}
+
+// fakeTimer is a pending call to fakeClock.After.
+type fakeTimer struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+func newFakeClock() *fakeClock {
+	c := &fakeClock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	c.cond = sync.NewCond(&c.mu)
+	return c
+}
+
+func (c *fakeClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *fakeClock) After(d time.Duration) <-chan time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ch := make(chan time.Time, 1)
+	c.waiters = append(c.waiters, fakeTimer{deadline: c.now.Add(d), ch: ch})
+	c.cond.Broadcast()
+	return ch
+}
+
+// Advance moves the time forward and fires the timers whose deadline has been reached.
+func (c *fakeClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	pending := c.waiters[:0]
+	for _, timer := range c.waiters {
+		if timer.deadline.After(c.now) {
+			pending = append(pending, timer)
+			continue
+		}
+		timer.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// BlockUntil waits until at least n timers are pending, so that a following Advance fires them.
+func (c *fakeClock) BlockUntil(n int) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for len(c.waiters) < n {
+		c.cond.Wait()
+	}
+}
+
+func TestCache_LRU(t *testing.T) {
+	var evicted []string
+	c := NewCache(2, WithEvictionCallback(func(key string, value int) {
+		evicted = append(evicted, key)
+	}))
+
+	c.Set("a", 1)
+	c.Set("b", 2)
+	_, ok := c.Get("a") // a becomes the most recently used
+	assert.True(t, ok)
+
+	c.Set("c", 3)
+	assert.Equal(t, []string{"b"}, evicted)
+	assert.Equal(t, []string{"c", "a"}, c.Keys())
+
+	_, ok = c.Get("b")
+	assert.False(t, ok)
+
+	c.Set("a", 10) // updating moves to the front without evicting
+	assert.Equal(t, []string{"a", "c"}, c.Keys())
+	assert.Equal(t, []string{"b"}, evicted)
+
+	assert.Equal(t, CacheStats{Hits: 1, Misses: 1, Evictions: 1}, c.Stats())
+}
+
+func TestCache_Peek(t *testing.T) {
+	c := NewCache[string, int](2)
+	c.Set("a", 1)
+	c.Set("b", 2)
+
+	value, ok := c.Peek("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, []string{"b", "a"}, c.Keys(), "Peek should not change recency")
+	assert.Equal(t, CacheStats{}, c.Stats(), "Peek should not change the counters")
+
+	c.Set("c", 3)
+	assert.False(t, c.Contains("a"))
+}
+
+func TestCache_Delete(t *testing.T) {
+	evictions := 0
+	c := NewCache(2, WithEvictionCallback(func(string, int) { evictions++ }))
+	c.Set("a", 1)
+
+	assert.True(t, c.Delete("a"))
+	assert.False(t, c.Delete("a"))
+	assert.Equal(t, 0, c.Len())
+	assert.Equal(t, int64(0), c.Cost())
+	assert.Equal(t, 0, evictions, "Deletes are not evictions")
+
+	c.Set("b", 2)
+	c.Clear()
+	assert.Equal(t, 0, c.Len())
+}
+
+func TestCache_Weigher(t *testing.T) {
+	var evicted []string
+	c := NewCache(10,
+		WithWeigher[string, []byte](func(v []byte) int64 { return int64(len(v)) }),
+		WithEvictionCallback(func(key string, value []byte) { evicted = append(evicted, key) }),
+	)
+
+	c.Set("a", make([]byte, 4))
+	c.Set("b", make([]byte, 4))
+	assert.Equal(t, int64(8), c.Cost())
+
+	c.Set("c", make([]byte, 5))
+	assert.Equal(t, []string{"a"}, evicted)
+	assert.Equal(t, int64(9), c.Cost())
+
+	c.Set("b", make([]byte, 1))
+	assert.Equal(t, int64(6), c.Cost(), "Updating should replace the previous cost")
+
+	assert.True(t, c.Set("full", make([]byte, 10)))
+	assert.Equal(t, []string{"full"}, c.Keys())
+	assert.Equal(t, []string{"a", "c", "b"}, evicted)
+	assert.Equal(t, int64(10), c.Cost())
+
+	assert.False(t, c.Set("huge", make([]byte, 11)), "An entry larger than the capacity cannot be kept")
+	assert.False(t, c.Set("full", make([]byte, 11)))
+	assert.Equal(t, []string{"full"}, c.Keys(), "A rejected value leaves the cache unchanged")
+	assert.Equal(t, int64(10), c.Cost())
+	assert.Equal(t, []string{"a", "c", "b"}, evicted, "A rejected value is not reported as evicted")
+}
+
+func TestCache_EvictIdle(t *testing.T) {
+	clock := newFakeClock()
+	var evicted []string
+	c := NewCache(10,
+		WithCacheClock[string, int](clock),
+		WithEvictionCallback(func(key string, value int) { evicted = append(evicted, key) }),
+	)
+
+	c.Set("a", 1)
+	clock.Advance(time.Minute)
+	c.Set("b", 2)
+	clock.Advance(time.Minute)
+	c.Set("c", 3)
+	c.Get("a") // a is fresh again
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, 1, c.EvictIdle(time.Minute))
+	assert.Equal(t, []string{"b"}, evicted)
+	assert.Equal(t, []string{"a", "c"}, c.Keys())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, 2, c.EvictIdle(time.Minute))
+	assert.Equal(t, uint64(3), c.Stats().Evictions)
+}
+
+func TestCache_CallbackCanReenter(t *testing.T) {
+	var c *Cache[string, int]
+	c = NewCache(1, WithEvictionCallback(func(key string, value int) {
+		c.Len() // must not deadlock
+	}))
+	c.Set("a", 1)
+	c.Set("b", 2)
+	assert.Equal(t, 1, c.Len())
+}
+
+func TestCache_Concurrent(t *testing.T) {
+	c := NewCache[int, int](50)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				c.Set(i*100+j, j)
+				c.Get(j)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 50, c.Len())
+	stats := c.Stats()
+	assert.Equal(t, uint64(2000), stats.Hits+stats.Misses)
+}
@@ -0,0 +1,24 @@
+package wrap
+
+import (
+	"time"
+)
+
+// Clock provides the current time and timers to time-aware types such as Cache and ExpiringMap.
+// Tests can supply a fake implementation to control time without sleeping.
+type Clock interface {
+	Now() time.Time
+	// After returns a channel that receives the current time once the duration has elapsed, like time.After.
+	After(d time.Duration) <-chan time.Time
+}
+
+// systemClock is the Clock backed by time.Now and time.After.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
@@ -0,0 +1,238 @@
+package wrap
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"time"
+)
+
+// Cloner is implemented by values able to return a deep copy of themselves. All the value wrappers of this
+// package implement it, and DeepCopy uses the Clone method of the types implementing it.
+type Cloner[T any] interface {
+	Clone() T
+}
+
+// CloneOption configures a call to DeepCopy.
+type CloneOption func(*cloneState)
+
+// WithCloneFunc sets the function used to copy values of type T, replacing the default deep copy.
+func WithCloneFunc[T any](clone func(T) T) CloneOption {
+	return func(c *cloneState) {
+		c.funcs[reflect.TypeFor[T]()] = cloneFunc(clone)
+	}
+}
+
+// DeepCopy returns a deep copy of the value, sharing no pointers, slices or maps with it. It recurses through
+// pointers, slices, arrays, maps, interfaces, the exported fields of structs and the types of this package,
+// and uses the Clone method of the other types implementing Cloner. Map keys, functions and channels are copied
+// as is, and so are times and locations, which are immutable. Big numbers of the math/big package are copied
+// with their own methods. Any other struct with unexported fields holding pointers, slices, maps or interfaces
+// makes DeepCopy panic, since they can neither be reached nor shared: such types must implement Cloner, or be
+// copied with WithCloneFunc. Nil pointers are copied as nil, and pointers reached more than once, including
+// cyclic ones, are copied once.
+//
+// DeepCopy does not call the Clone method of the value it is given, nor of the values it points to, so Clone
+// methods can be implemented with DeepCopy. They recurse forever if they deep copy a value holding themselves
+// in any other way.
+func DeepCopy[T any](value T, options ...CloneOption) T {
+	c := &cloneState{funcs: map[reflect.Type]func(reflect.Value) reflect.Value{}, pointers: map[clonePointer]reflect.Value{}}
+	for _, option := range options {
+		option(c)
+	}
+	var copied T
+	reflect.ValueOf(&copied).Elem().Set(c.copy(reflect.ValueOf(&value).Elem(), false))
+	return copied
+}
+
+// reflectCloner is implemented by the types of this package with unexported fields, so that DeepCopy can copy
+// them. The pointer receiver returns a copy of the value it points to.
+type reflectCloner interface {
+	cloneReflect(c *cloneState) reflect.Value
+}
+
+// sharedTypes holds the immutable standard library types that DeepCopy copies as is, even in unexported fields.
+var sharedTypes = map[reflect.Type]bool{
+	reflect.TypeFor[time.Time]():      true,
+	reflect.TypeFor[*time.Location](): true,
+}
+
+// defaultCloneFuncs copies the mutable standard library types DeepCopy cannot recurse into.
+var defaultCloneFuncs = map[reflect.Type]func(reflect.Value) reflect.Value{
+	reflect.TypeFor[*big.Int]():   cloneFunc(func(x *big.Int) *big.Int { return new(big.Int).Set(x) }),
+	reflect.TypeFor[*big.Float](): cloneFunc(func(x *big.Float) *big.Float { return new(big.Float).Copy(x) }),
+	reflect.TypeFor[*big.Rat]():   cloneFunc(func(x *big.Rat) *big.Rat { return new(big.Rat).Set(x) }),
+}
+
+// cloneFunc converts a function copying values of type T into one copying reflect values.
+func cloneFunc[T any](clone func(T) T) func(reflect.Value) reflect.Value {
+	return func(value reflect.Value) reflect.Value {
+		return reflect.ValueOf(clone(value.Interface().(T)))
+	}
+}
+
+// cloneValue returns a deep copy of the value, made with the configuration and the copied pointers of c.
+func cloneValue[T any](c *cloneState, value T) T {
+	var copied T
+	reflect.ValueOf(&copied).Elem().Set(c.clone(reflect.ValueOf(&value).Elem()))
+	return copied
+}
+
+// cloneState holds the configuration and the copied pointers of a call to DeepCopy.
+type cloneState struct {
+	funcs    map[reflect.Type]func(reflect.Value) reflect.Value
+	pointers map[clonePointer]reflect.Value
+}
+
+// clonePointer identifies a pointer already copied.
+type clonePointer struct {
+	typ reflect.Type
+	ptr uintptr
+}
+
+// clone returns a deep copy of the value, with the same type.
+func (c *cloneState) clone(value reflect.Value) reflect.Value {
+	return c.copy(value, true)
+}
+
+// copy returns a deep copy of the value, with the same type. The Clone method of the value, and of the values
+// it points to, is only used if useClone is set.
+func (c *cloneState) copy(value reflect.Value, useClone bool) reflect.Value {
+	typ := value.Type()
+	var key clonePointer
+	if typ.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return value
+		}
+		key = clonePointer{typ: typ, ptr: value.Pointer()}
+		if copied, ok := c.pointers[key]; ok {
+			return copied
+		}
+	}
+	if copied, ok := c.custom(value, useClone); ok {
+		if typ.Kind() == reflect.Pointer {
+			c.pointers[key] = copied
+		}
+		return copied
+	}
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		copied := reflect.New(typ.Elem())
+		c.pointers[key] = copied
+		copied.Elem().Set(c.copy(value.Elem(), useClone))
+		return copied
+	case reflect.Interface:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.New(typ).Elem()
+		copied.Set(c.copy(value.Elem(), useClone))
+		return copied
+	case reflect.Slice:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeSlice(typ, value.Len(), value.Len())
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(c.clone(value.Index(i)))
+		}
+		return copied
+	case reflect.Array:
+		copied := reflect.New(typ).Elem()
+		for i := 0; i < value.Len(); i++ {
+			copied.Index(i).Set(c.clone(value.Index(i)))
+		}
+		return copied
+	case reflect.Map:
+		if value.IsNil() {
+			return value
+		}
+		copied := reflect.MakeMapWithSize(typ, value.Len())
+		for iter := value.MapRange(); iter.Next(); {
+			copied.SetMapIndex(iter.Key(), c.clone(iter.Value()))
+		}
+		return copied
+	case reflect.Struct:
+		copied := reflect.New(typ).Elem()
+		copied.Set(value)
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			if field.IsExported() {
+				copied.Field(i).Set(c.clone(value.Field(i)))
+			} else if holdsReferences(field.Type) {
+				panic(fmt.Sprintf("wrap: cannot deep copy %s: unexported field %s holds references, "+
+					"implement Cloner or use WithCloneFunc", typ, field.Name))
+			}
+		}
+		return copied
+	}
+	return value
+}
+
+// custom copies the value with a clone function, the reflectCloner hook of the types of this package or, if
+// useClone is set, the Clone method of other types, and reports whether it did.
+func (c *cloneState) custom(value reflect.Value, useClone bool) (reflect.Value, bool) {
+	typ := value.Type()
+	if clone, ok := c.funcs[typ]; ok {
+		return cloneResult(typ, clone(value)), true
+	}
+	if sharedTypes[typ] {
+		return value, true
+	}
+	if clone, ok := defaultCloneFuncs[typ]; ok {
+		return clone(value), true
+	}
+	if !value.CanInterface() || typ.Kind() == reflect.Interface {
+		return reflect.Value{}, false
+	}
+	if typ.Kind() != reflect.Pointer && reflect.PointerTo(typ).Implements(reflectClonerType) {
+		ptr := reflect.New(typ)
+		if value.CanAddr() {
+			ptr = value.Addr()
+		} else {
+			ptr.Elem().Set(value)
+		}
+		return ptr.Interface().(reflectCloner).cloneReflect(c), true
+	}
+	if useClone && typ.PkgPath() != wrapPkgPath {
+		if method, ok := typ.MethodByName("Clone"); ok && method.Type.NumIn() == 1 &&
+			method.Type.NumOut() == 1 && method.Type.Out(0) == typ {
+			return value.Method(method.Index).Call(nil)[0], true
+		}
+	}
+	return reflect.Value{}, false
+}
+
+// reflectClonerType is the type of the reflectCloner interface.
+var reflectClonerType = reflect.TypeFor[reflectCloner]()
+
+// holdsReferences reports whether values of the type may hold pointers, slices, maps or interfaces,
+// which a copy would share.
+func holdsReferences(typ reflect.Type) bool {
+	if sharedTypes[typ] {
+		return false
+	}
+	switch typ.Kind() {
+	case reflect.Pointer, reflect.UnsafePointer, reflect.Slice, reflect.Map, reflect.Interface:
+		return true
+	case reflect.Array:
+		return typ.Len() > 0 && holdsReferences(typ.Elem())
+	case reflect.Struct:
+		for i := 0; i < typ.NumField(); i++ {
+			if holdsReferences(typ.Field(i).Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// cloneResult returns the value returned by a custom clone function as a value of type typ, which may be an interface.
+func cloneResult(typ reflect.Type, value reflect.Value) reflect.Value {
+	copied := reflect.New(typ).Elem()
+	if value.IsValid() {
+		copied.Set(value)
+	}
+	return copied
+}
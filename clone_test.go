@@ -0,0 +1,267 @@
+package wrap
+
+import (
+	"math/big"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type cloneConfig struct {
+	Name   string
+	Tags   []string
+	Limits map[string]int
+	Parent *cloneConfig
+}
+
+func TestDeepCopy_NestedWrappers(t *testing.T) {
+	config := &cloneConfig{Name: "a", Tags: []string{"x"}, Limits: map[string]int{"cpu": 1}}
+	original := NewMap(map[string]Slice[Ptr[cloneConfig]]{
+		"configs": NewSlice([]Ptr[cloneConfig]{NewPtr(config), NewNilPtr[cloneConfig]()}),
+	})
+
+	cloned := original.Clone()
+	assert.Equal(t, original, cloned)
+
+	clonedConfig := cloned.X["configs"].X[0].X
+	assert.NotSame(t, config, clonedConfig)
+	clonedConfig.Name = "b"
+	clonedConfig.Tags[0] = "y"
+	clonedConfig.Limits["cpu"] = 2
+	cloned.X["configs"].X[1] = NewPtr(&cloneConfig{})
+	cloned.X["extra"] = Slice[Ptr[cloneConfig]]{}
+
+	assert.Equal(t, &cloneConfig{Name: "a", Tags: []string{"x"}, Limits: map[string]int{"cpu": 1}}, config)
+	assert.Nil(t, original.X["configs"].X[1].X)
+	assert.False(t, original.Contains("extra"))
+}
+
+func TestDeepCopy_Interfaces(t *testing.T) {
+	object := NewObject(map[string]any{
+		"server": map[string]any{"ports": []any{80, 443}},
+		"null":   nil,
+	})
+	clonedObject := object.Clone()
+	assert.Equal(t, object, clonedObject)
+
+	assert.NoError(t, clonedObject.Set("server.ports[0]", 8080))
+	port, _ := object.Get("server.ports[0]")
+	assert.Equal(t, 80, port)
+
+	original := map[string]any{"list": NewSlice([]any{NewMap(map[string]any{"a": 1})})}
+	cloned := DeepCopy(original)
+	cloned["list"].(Slice[any]).X[0].(Map[string, any]).X["a"] = 2
+	assert.Equal(t, 1, original["list"].(Slice[any]).X[0].(Map[string, any]).X["a"])
+}
+
+func TestDeepCopy_SharedAndCyclicPointers(t *testing.T) {
+	root := &cloneConfig{Name: "root"}
+	root.Parent = root
+	shared := []*cloneConfig{root, root}
+
+	cloned := DeepCopy(shared)
+	assert.NotSame(t, root, cloned[0])
+	assert.Same(t, cloned[0], cloned[1])
+	assert.Same(t, cloned[0], cloned[0].Parent)
+}
+
+type cloneCounter struct {
+	count   int
+	updated time.Time
+}
+
+type cloneHidden struct {
+	values []int
+}
+
+func TestDeepCopy_UnexportedFields(t *testing.T) {
+	original := cloneCounter{count: 1, updated: time.Now()}
+	assert.Equal(t, original, DeepCopy(original))
+
+	assert.PanicsWithValue(t,
+		"wrap: cannot deep copy wrap.cloneHidden: unexported field values holds references, implement Cloner or use WithCloneFunc",
+		func() { DeepCopy(NewSlice([]cloneHidden{{values: []int{1}}})) })
+
+	hidden := []cloneHidden{{values: []int{1}}}
+	cloned := DeepCopy(hidden, WithCloneFunc(func(h cloneHidden) cloneHidden {
+		return cloneHidden{values: slices.Clone(h.values)}
+	}))
+	cloned[0].values[0] = 2
+	assert.Equal(t, []int{1}, hidden[0].values)
+}
+
+func TestDeepCopy_PackageTypes(t *testing.T) {
+	type containers struct {
+		Ordered   Map[string, *OrderedMap[string, []int]]
+		Sorted    *SortedMap[string, []int]
+		Bi        *BiMap[string, int]
+		Deque     *Deque[[]int]
+		Heap      *Heap[[]int]
+		Handle    *HeapHandle[[]int]
+		SyncMap   *SyncMap[string, []int]
+		SyncSlice *SyncSlice[[]int]
+		Atomic    *AtomicPtr[[]int]
+		ImmSlice  ImmutableSlice[[]int]
+		ImmMap    ImmutableMap[string, []int]
+		ObsSlice  *ObservableSlice[[]int]
+		ObsMap    *ObservableMap[string, []int]
+		ObsPtr    *ObservablePtr[[]int]
+		Cache     *Cache[string, []int]
+		Expiring  *ExpiringMap[string, []int]
+		Big       *big.Int
+	}
+	heap := NewHeap(func(a, b []int) bool { return a[0] < b[0] })
+	handle := heap.Push([]int{1})
+	original := containers{
+		Ordered:   NewMap(map[string]*OrderedMap[string, []int]{"m": NewOrderedMap(MapEntry[string, []int]{Key: "a", Value: []int{1}})}),
+		Sorted:    NewSortedMap[string, []int]().Set("a", []int{1}),
+		Bi:        NewBiMap[string, int]().Set("a", 1),
+		Deque:     NewDeque([]int{1}),
+		Heap:      heap,
+		Handle:    handle,
+		SyncMap:   NewSyncMap(map[string][]int{"a": {1}}),
+		SyncSlice: NewSyncSlice([][]int{{1}}),
+		Atomic:    NewAtomicPtr(&[]int{1}),
+		ImmSlice:  NewImmutableSlice([]int{1}),
+		ImmMap:    NewImmutableMap(MapEntry[string, []int]{Key: "a", Value: []int{1}}),
+		ObsSlice:  NewObservableSlice([][]int{{1}}),
+		ObsMap:    NewObservableMap(map[string][]int{"a": {1}}),
+		ObsPtr:    NewObservablePtr(&[]int{1}),
+		Cache:     NewCache[string, []int](10),
+		Expiring:  NewExpiringMap[string, []int](time.Hour),
+		Big:       big.NewInt(1),
+	}
+	original.Cache.Set("a", []int{1})
+	original.Expiring.Set("a", []int{1})
+	notified := 0
+	original.ObsMap.Subscribe(func(MapEvent[string, []int]) { notified++ })
+
+	cloned := DeepCopy(original)
+
+	ordered := cloned.Ordered.X["m"]
+	ordered.Set("a", []int{99}).Set("b", []int{2})
+	cloned.Sorted.Set("a", []int{99}).Set("b", []int{2})
+	cloned.Bi.Set("b", 2)
+	cloned.Deque.PushBack([]int{2})
+	cloned.Heap.Update(cloned.Handle, []int{99})
+	cloned.Heap.Push([]int{0})
+	cloned.SyncMap.Set("a", []int{99})
+	cloned.SyncSlice.Append([]int{2})
+	cloned.Atomic.SetValue([]int{99})
+	cloned.ObsMap.Set("a", []int{99})
+	cloned.ObsPtr.SetValue([]int{99})
+	cloned.Cache.Set("b", []int{2})
+	cloned.Expiring.Set("b", []int{2})
+	cloned.Big.SetInt64(99)
+
+	mutate := func(values []int, ok bool) {
+		assert.True(t, ok)
+		values[0] = 99
+	}
+	mutate(cloned.Deque.PeekFront())
+	mutate(cloned.SyncSlice.ValueAt(0))
+	mutate(cloned.ImmSlice.ValueAt(0))
+	mutate(cloned.ImmMap.Get("a"))
+	mutate(cloned.ObsSlice.ValueAt(0))
+	mutate(cloned.Cache.Get("a"))
+	mutate(cloned.Expiring.Get("a"))
+
+	value, _ := original.Ordered.X["m"].Get("a")
+	assert.Equal(t, []int{1}, value)
+	assert.Equal(t, 1, original.Ordered.X["m"].Len())
+	value, _ = original.Sorted.Get("a")
+	assert.Equal(t, []int{1}, value)
+	assert.Equal(t, 1, original.Sorted.Len())
+	assert.Equal(t, 1, original.Bi.Len())
+	assert.Equal(t, NewSlice([][]int{{1}}), original.Deque.ToSlice())
+	assert.Equal(t, NewSlice([][]int{{1}}), original.Heap.Values())
+	assert.Equal(t, []int{1}, original.Handle.Value())
+	assert.True(t, original.Heap.Contains(original.Handle))
+	assert.False(t, original.Heap.Contains(cloned.Handle))
+	assert.True(t, cloned.Heap.Contains(cloned.Handle))
+	assert.Equal(t, map[string][]int{"a": {1}}, original.SyncMap.Snapshot().X)
+	assert.Equal(t, [][]int{{1}}, original.SyncSlice.Unwrap())
+	assert.Equal(t, []int{1}, *original.Atomic.Load())
+	assert.Equal(t, NewSlice([][]int{{1}}), original.ImmSlice.ToSlice())
+	assert.Equal(t, map[string][]int{"a": {1}}, original.ImmMap.ToMap().X)
+	assert.Equal(t, [][]int{{1}}, original.ObsSlice.Unwrap())
+	assert.Equal(t, map[string][]int{"a": {1}}, original.ObsMap.Unwrap())
+	assert.Equal(t, 0, notified, "Subscribers must not be copied")
+	assert.Equal(t, []int{1}, *original.ObsPtr.Unwrap())
+	assert.Equal(t, []string{"a"}, original.Cache.Keys())
+	value, _ = original.Cache.Peek("a")
+	assert.Equal(t, []int{1}, value)
+	assert.Equal(t, []string{"a"}, original.Expiring.Keys())
+	value, _ = original.Expiring.Get("a")
+	assert.Equal(t, []int{1}, value)
+	assert.Equal(t, int64(1), original.Big.Int64())
+}
+
+type cloneNode struct {
+	Name     string
+	Children []*cloneNode
+}
+
+var cloneNodeCalls int
+
+func (n *cloneNode) Clone() *cloneNode {
+	cloneNodeCalls++
+	return DeepCopy(n)
+}
+
+func TestDeepCopy_ClonerPointers(t *testing.T) {
+	cloneNodeCalls = 0
+	assert.NotPanics(t, func() {
+		cloned := DeepCopy(NewMap(map[string]*cloneNode{"a": nil}))
+		assert.Nil(t, cloned.X["a"])
+	})
+	assert.Equal(t, 0, cloneNodeCalls, "Clone must not be called on nil pointers")
+
+	root := &cloneNode{Name: "root", Children: []*cloneNode{{Name: "child"}}}
+	cloned := root.Clone()
+	cloned.Children[0].Name = "changed"
+	assert.Equal(t, "child", root.Children[0].Name)
+	assert.Equal(t, 2, cloneNodeCalls, "Clone must call DeepCopy on itself without recursing")
+}
+
+func TestDeepCopy_CloneFunc(t *testing.T) {
+	calls := 0
+	original := NewSlice([]*cloneConfig{{Name: "a"}, {Name: "b"}})
+
+	cloned := DeepCopy(original, WithCloneFunc(func(c *cloneConfig) *cloneConfig {
+		calls++
+		return &cloneConfig{Name: c.Name + "'"}
+	}))
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "a'", cloned.X[0].Name)
+	assert.Equal(t, "a", original.X[0].Name)
+}
+
+func TestDeepCopy_Cloner(t *testing.T) {
+	var _ Cloner[Map[string, int]] = Map[string, int]{}
+	var _ Cloner[Slice[int]] = &Slice[int]{}
+
+	optional := NewOptional([]int{1})
+	cloned := DeepCopy(map[string]Optional[[]int]{"a": optional, "b": NewNullOptional[[]int]()})
+	value, _ := cloned["a"].Value()
+	value[0] = 2
+	original, _ := optional.Value()
+	assert.Equal(t, []int{1}, original)
+	assert.True(t, cloned["b"].IsNull())
+
+	set := NewSet(1, 2)
+	clonedSet := set.Clone()
+	clonedSet.Add(3)
+	assert.Equal(t, 2, set.Len())
+
+	multi := NewMultiMap(map[string][]int{"a": {1}})
+	clonedMulti := multi.Clone()
+	clonedMulti.X["a"][0] = 2
+	assert.Equal(t, []int{1}, multi.X["a"])
+
+	var empty any
+	assert.Nil(t, DeepCopy(empty))
+	assert.Nil(t, DeepCopy[*int](nil))
+}
@@ -0,0 +1,231 @@
+package wrap
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// dequeMinCapacity is the smallest buffer a growable Deque allocates or shrinks to.
+const dequeMinCapacity = 8
+
+// Deque is a generic double-ended queue of values of type T backed by a ring buffer,
+// with O(1) amortized push and pop at both ends.
+// A growable Deque (the zero value or NewDeque) resizes as needed, releasing memory as it empties.
+// A fixed Deque (NewFixedDeque) never grows: pushing onto a full one overwrites the element at the opposite end,
+// which makes it a rolling window over the latest values.
+type Deque[T any] struct {
+	buf   []T
+	head  int
+	size  int
+	fixed bool
+}
+
+// NewDeque creates a new growable Deque instance holding the provided values, front to back.
+func NewDeque[T any](values ...T) *Deque[T] {
+	d := &Deque[T]{}
+	d.PushBack(values...)
+	return d
+}
+
+// NewFixedDeque creates a new empty Deque instance with a fixed capacity.
+// When the deque is full, PushBack overwrites the front (oldest) element and PushFront overwrites the back element.
+func NewFixedDeque[T any](capacity int) *Deque[T] {
+	return &Deque[T]{
+		buf:   make([]T, max(capacity, 0)),
+		fixed: true,
+	}
+}
+
+// PushBack adds one or more values to the back of the deque.
+func (d *Deque[T]) PushBack(values ...T) {
+	for _, v := range values {
+		if d.size == len(d.buf) {
+			if d.fixed {
+				if len(d.buf) == 0 {
+					continue
+				}
+				d.buf[d.head] = v
+				d.head = d.index(1)
+				continue
+			}
+			d.resize(max(2*len(d.buf), dequeMinCapacity))
+		}
+		d.buf[d.index(d.size)] = v
+		d.size++
+	}
+}
+
+// PushFront adds one or more values to the front of the deque, keeping their order, like Slice.Prepend.
+func (d *Deque[T]) PushFront(values ...T) {
+	for i := len(values) - 1; i >= 0; i-- {
+		if d.size == len(d.buf) {
+			if d.fixed {
+				if len(d.buf) == 0 {
+					continue
+				}
+				d.head = d.index(-1)
+				d.buf[d.head] = values[i]
+				continue
+			}
+			d.resize(max(2*len(d.buf), dequeMinCapacity))
+		}
+		d.head = d.index(-1)
+		d.buf[d.head] = values[i]
+		d.size++
+	}
+}
+
+// PopFront removes and returns the front value of the deque, or false if the deque is empty.
+func (d *Deque[T]) PopFront() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	value := d.buf[d.head]
+	d.buf[d.head] = zero
+	d.head = d.index(1)
+	d.size--
+	d.shrink()
+	return value, true
+}
+
+// PopBack removes and returns the back value of the deque, or false if the deque is empty.
+func (d *Deque[T]) PopBack() (T, bool) {
+	var zero T
+	if d.size == 0 {
+		return zero, false
+	}
+	i := d.index(d.size - 1)
+	value := d.buf[i]
+	d.buf[i] = zero
+	d.size--
+	d.shrink()
+	return value, true
+}
+
+// PeekFront returns the front value of the deque without removing it, or false if the deque is empty.
+func (d *Deque[T]) PeekFront() (T, bool) {
+	return d.At(0)
+}
+
+// PeekBack returns the back value of the deque without removing it, or false if the deque is empty.
+func (d *Deque[T]) PeekBack() (T, bool) {
+	return d.At(d.size - 1)
+}
+
+// At retrieves the value at the specified index, counting from the front, and a boolean indicating success.
+func (d *Deque[T]) At(index int) (T, bool) {
+	var zero T
+	if index < 0 || index >= d.size {
+		return zero, false
+	}
+	return d.buf[d.index(index)], true
+}
+
+// SetAt sets the value at the specified index, counting from the front, and returns whether the operation was successful.
+func (d *Deque[T]) SetAt(index int, value T) bool {
+	if index < 0 || index >= d.size {
+		return false
+	}
+	d.buf[d.index(index)] = value
+	return true
+}
+
+// Len returns the number of values in the deque.
+func (d *Deque[T]) Len() int {
+	return d.size
+}
+
+// Capacity returns the number of values the deque can hold before growing, or before overwriting when fixed.
+func (d *Deque[T]) Capacity() int {
+	return len(d.buf)
+}
+
+// IsEmpty returns true if the deque is empty, otherwise false.
+func (d *Deque[T]) IsEmpty() bool {
+	return d.size == 0
+}
+
+// IsFull returns true if the deque is fixed and holds as many values as its capacity.
+func (d *Deque[T]) IsFull() bool {
+	return d.fixed && d.size == len(d.buf)
+}
+
+// Clear removes all values from the deque. A fixed deque keeps its capacity.
+func (d *Deque[T]) Clear() {
+	if d.fixed {
+		clear(d.buf)
+	} else {
+		d.buf = nil
+	}
+	d.head = 0
+	d.size = 0
+}
+
+// ToSlice returns a new Slice holding the values of the deque, front to back.
+func (d *Deque[T]) ToSlice() Slice[T] {
+	values := make([]T, d.size)
+	n := copy(values, d.buf[d.head:min(d.head+d.size, len(d.buf))])
+	copy(values[n:], d.buf[:d.size-n])
+	return NewSlice(values)
+}
+
+// Iter returns a lazy sequence over the values of the deque, front to back.
+func (d *Deque[T]) Iter() Seq[T] {
+	return func(yield func(T) bool) {
+		for i := 0; i < d.size; i++ {
+			if !yield(d.buf[d.index(i)]) {
+				return
+			}
+		}
+	}
+}
+
+// index converts an offset from the head into a buffer index.
+func (d *Deque[T]) index(offset int) int {
+	n := len(d.buf)
+	return ((d.head+offset)%n + n) % n
+}
+
+// resize moves the values into a new buffer of the given capacity, starting at index 0.
+func (d *Deque[T]) resize(capacity int) {
+	values := d.ToSlice()
+	d.buf = make([]T, capacity)
+	copy(d.buf, values.X)
+	d.head = 0
+}
+
+// shrink halves the buffer of a growable deque once it is a quarter full.
+func (d *Deque[T]) shrink() {
+	if d.fixed || len(d.buf) <= dequeMinCapacity || d.size > len(d.buf)/4 {
+		return
+	}
+	d.resize(len(d.buf) / 2)
+}
+
+// UnmarshalJSON unmarshals a JSON array into the Deque, front to back.
+// A fixed deque keeps its capacity, so only the last values of a longer array are kept.
+func (d *Deque[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	d.Clear()
+	d.PushBack(values...)
+	return nil
+}
+
+// MarshalJSON marshals the Deque into a JSON array, front to back.
+func (d Deque[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(d.ToSlice().X)
+}
+
+// cloneReflect returns a deep copy of the Deque for DeepCopy, keeping the layout of its buffer.
+func (d *Deque[T]) cloneReflect(c *cloneState) reflect.Value {
+	copied := Deque[T]{buf: make([]T, len(d.buf)), head: d.head, size: d.size, fixed: d.fixed}
+	for offset := 0; offset < d.size; offset++ {
+		i := d.index(offset)
+		copied.buf[i] = cloneValue(c, d.buf[i])
+	}
+	return reflect.ValueOf(copied)
+}
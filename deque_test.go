@@ -0,0 +1,151 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeque_PushPop(t *testing.T) {
+	var d Deque[int]
+	d.PushBack(3, 4)
+	d.PushFront(1, 2)
+	assert.Equal(t, []int{1, 2, 3, 4}, d.ToSlice().X)
+	assert.Equal(t, 4, d.Len())
+
+	front, ok := d.PeekFront()
+	assert.True(t, ok)
+	assert.Equal(t, 1, front)
+	back, ok := d.PeekBack()
+	assert.True(t, ok)
+	assert.Equal(t, 4, back)
+
+	value, ok := d.PopFront()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	value, ok = d.PopBack()
+	assert.True(t, ok)
+	assert.Equal(t, 4, value)
+	assert.Equal(t, []int{2, 3}, d.ToSlice().X)
+
+	d.Clear()
+	assert.True(t, d.IsEmpty())
+	_, ok = d.PopFront()
+	assert.False(t, ok)
+	_, ok = d.PopBack()
+	assert.False(t, ok)
+	_, ok = d.PeekBack()
+	assert.False(t, ok)
+}
+
+func TestDeque_AtAndSetAt(t *testing.T) {
+	d := NewDeque(1, 2, 3)
+
+	tests := []struct {
+		index    int
+		expected int
+		ok       bool
+	}{
+		{0, 1, true},
+		{2, 3, true},
+		{3, 0, false},  // out of bounds
+		{-1, 0, false}, // negative index
+	}
+
+	for _, tt := range tests {
+		value, ok := d.At(tt.index)
+		assert.Equal(t, tt.ok, ok)
+		assert.Equal(t, tt.expected, value)
+	}
+
+	assert.True(t, d.SetAt(1, 20))
+	assert.False(t, d.SetAt(5, 20))
+	assert.Equal(t, []int{1, 20, 3}, d.ToSlice().X)
+}
+
+func TestDeque_WrapAroundAndGrowth(t *testing.T) {
+	d := NewDeque[int]()
+	reference := []int{}
+
+	// Interleave pushes and pops so the head wraps around the buffer several times.
+	for i := 0; i < 100; i++ {
+		d.PushBack(i)
+		reference = append(reference, i)
+		if i%3 == 0 {
+			d.PushFront(-i)
+			reference = append([]int{-i}, reference...)
+		}
+		if i%4 == 0 {
+			d.PopFront()
+			reference = reference[1:]
+		}
+	}
+	assert.Equal(t, reference, d.ToSlice().X)
+	assert.Equal(t, reference, d.Iter().Collect().X)
+
+	for !d.IsEmpty() {
+		d.PopBack()
+	}
+	assert.LessOrEqual(t, d.Capacity(), dequeMinCapacity, "An emptied deque should release its buffer")
+}
+
+func TestDeque_PopReleasesReferences(t *testing.T) {
+	value := 1
+	d := NewDeque(&value, &value)
+	d.PopFront()
+	d.PopBack()
+	for _, slot := range d.buf {
+		assert.Nil(t, slot, "Popped slots should be zeroed")
+	}
+}
+
+func TestDeque_Fixed(t *testing.T) {
+	d := NewFixedDeque[int](3)
+	d.PushBack(1, 2, 3)
+	assert.True(t, d.IsFull())
+
+	d.PushBack(4, 5)
+	assert.Equal(t, []int{3, 4, 5}, d.ToSlice().X, "PushBack should overwrite the oldest values")
+	assert.Equal(t, 3, d.Capacity())
+
+	d.PushFront(0)
+	assert.Equal(t, []int{0, 3, 4}, d.ToSlice().X, "PushFront should overwrite the back value")
+
+	d.PopFront()
+	assert.False(t, d.IsFull())
+	d.Clear()
+	assert.Equal(t, 3, d.Capacity(), "A fixed deque should keep its capacity")
+
+	empty := NewFixedDeque[int](0)
+	empty.PushBack(1)
+	empty.PushFront(1)
+	assert.True(t, empty.IsEmpty())
+}
+
+func TestDeque_JSON(t *testing.T) {
+	d := NewDeque("a", "b")
+	d.PushFront("z")
+	data, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.Equal(t, `["z","a","b"]`, string(data))
+
+	var decoded Deque[string]
+	err = json.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"z", "a", "b"}, decoded.ToSlice().X)
+
+	window := NewFixedDeque[int](2)
+	err = json.Unmarshal([]byte(`[1,2,3]`), window)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, window.ToSlice().X)
+
+	type Test struct {
+		Recent Deque[int] `json:"recent"`
+	}
+	var test Test
+	test.Recent.PushBack(1, 2)
+	data, err = json.Marshal(test)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"recent":[1,2]}`, string(data), "By-value fields should keep their data")
+}
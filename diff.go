@@ -0,0 +1,197 @@
+package wrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"maps"
+	"math/big"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ChangeKind identifies the kind of a Change found by Diff.
+type ChangeKind string
+
+const (
+	// ChangeAdded reports a key or array element present only in the second value.
+	ChangeAdded ChangeKind = "added"
+	// ChangeRemoved reports a key or array element present only in the first value.
+	ChangeRemoved ChangeKind = "removed"
+	// ChangeChanged reports a value that differs between the two values.
+	ChangeChanged ChangeKind = "changed"
+)
+
+// Change is a single difference found by Diff, located by a JSON Pointer (RFC 6901).
+// Values are in their generic JSON representation, with numbers as json.Number.
+type Change struct {
+	Kind ChangeKind `json:"kind"`
+	Path string     `json:"path"`
+	Old  any        `json:"old"`
+	New  any        `json:"new"`
+}
+
+// Changes is the list of differences between two values, as returned by Diff.
+type Changes []Change
+
+// Diff compares the JSON representations of a and b and returns the changes turning a into b.
+// Objects are compared key by key and arrays index by index, recursing into nested values, so wrappers such as
+// Map, Slice, Ptr and Object can be compared with each other and with plain Go values.
+// Changes are sorted by key; removed array elements are listed from the last one.
+func Diff(a, b any) (Changes, error) {
+	left, err := toJSONTree(a)
+	if err != nil {
+		return nil, err
+	}
+	right, err := toJSONTree(b)
+	if err != nil {
+		return nil, err
+	}
+	changes := Changes{}
+	diffJSONTree("", left, right, &changes)
+	return changes, nil
+}
+
+// Patch returns the RFC 6902 JSON Patch applying the changes.
+func (c Changes) Patch() Patch {
+	patch := make(Patch, 0, len(c))
+	for _, change := range c {
+		switch change.Kind {
+		case ChangeAdded:
+			patch = append(patch, PatchOperation{Op: PatchAdd, Path: change.Path, Value: change.New})
+		case ChangeRemoved:
+			patch = append(patch, PatchOperation{Op: PatchRemove, Path: change.Path})
+		case ChangeChanged:
+			patch = append(patch, PatchOperation{Op: PatchReplace, Path: change.Path, Value: change.New})
+		}
+	}
+	return patch
+}
+
+// diffJSONTree appends the changes turning a into b, both located at the provided JSON Pointer.
+func diffJSONTree(path string, a, b any, changes *Changes) {
+	switch left := a.(type) {
+	case map[string]any:
+		right, ok := b.(map[string]any)
+		if !ok {
+			break
+		}
+		keys := slices.Sorted(maps.Keys(left))
+		for key := range right {
+			if _, ok := left[key]; !ok {
+				keys = append(keys, key)
+			}
+		}
+		slices.Sort(keys)
+		for _, key := range keys {
+			child := path + "/" + escapeJSONPointer(key)
+			leftValue, inLeft := left[key]
+			rightValue, inRight := right[key]
+			switch {
+			case !inRight:
+				*changes = append(*changes, Change{Kind: ChangeRemoved, Path: child, Old: leftValue})
+			case !inLeft:
+				*changes = append(*changes, Change{Kind: ChangeAdded, Path: child, New: rightValue})
+			default:
+				diffJSONTree(child, leftValue, rightValue, changes)
+			}
+		}
+		return
+	case []any:
+		right, ok := b.([]any)
+		if !ok {
+			break
+		}
+		common := min(len(left), len(right))
+		for i := 0; i < common; i++ {
+			diffJSONTree(path+"/"+strconv.Itoa(i), left[i], right[i], changes)
+		}
+		for i := common; i < len(right); i++ {
+			*changes = append(*changes, Change{Kind: ChangeAdded, Path: path + "/" + strconv.Itoa(i), New: right[i]})
+		}
+		for i := len(left) - 1; i >= common; i-- {
+			*changes = append(*changes, Change{Kind: ChangeRemoved, Path: path + "/" + strconv.Itoa(i), Old: left[i]})
+		}
+		return
+	}
+	if !jsonTreeEqual(a, b) {
+		*changes = append(*changes, Change{Kind: ChangeChanged, Path: path, Old: a, New: b})
+	}
+}
+
+// toJSONTree returns the generic JSON representation of a value, with numbers as json.Number.
+// The result never shares memory with the value.
+func toJSONTree(value any) (any, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var tree any
+	if err := decoder.Decode(&tree); err != nil {
+		return nil, err
+	}
+	return tree, nil
+}
+
+// jsonTreeEqual returns true if two generic JSON representations are equal. Numbers are compared by value.
+func jsonTreeEqual(a, b any) bool {
+	switch left := a.(type) {
+	case map[string]any:
+		right, ok := b.(map[string]any)
+		if !ok || len(left) != len(right) {
+			return false
+		}
+		for key, value := range left {
+			other, ok := right[key]
+			if !ok || !jsonTreeEqual(value, other) {
+				return false
+			}
+		}
+		return true
+	case []any:
+		right, ok := b.([]any)
+		if !ok || len(left) != len(right) {
+			return false
+		}
+		for i := range left {
+			if !jsonTreeEqual(left[i], right[i]) {
+				return false
+			}
+		}
+		return true
+	case json.Number:
+		right, ok := b.(json.Number)
+		return ok && jsonNumberEqual(left, right)
+	}
+	return a == b
+}
+
+// jsonNumberEqual compares two JSON numbers by value without losing precision: integer literals are compared
+// exactly, and other numbers with enough precision to tell apart any two distinct literals of their length.
+func jsonNumberEqual(a, b json.Number) bool {
+	if a == b {
+		return true
+	}
+	if x, err := a.Int64(); err == nil {
+		if y, err := b.Int64(); err == nil {
+			return x == y
+		}
+	}
+	if isJSONInteger(a) && isJSONInteger(b) {
+		x, okX := new(big.Int).SetString(string(a), 10)
+		y, okY := new(big.Int).SetString(string(b), 10)
+		return okX && okY && x.Cmp(y) == 0
+	}
+	// A decimal literal of n digits needs about 3.33 bits per digit to be told apart from its neighbours.
+	prec := uint(4*(len(a)+len(b)) + 64)
+	x, _, errX := big.ParseFloat(string(a), 10, prec, big.ToNearestEven)
+	y, _, errY := big.ParseFloat(string(b), 10, prec, big.ToNearestEven)
+	return errX == nil && errY == nil && x.Cmp(y) == 0
+}
+
+// isJSONInteger reports whether a JSON number is written without a fraction or an exponent.
+func isJSONInteger(n json.Number) bool {
+	return !strings.ContainsAny(string(n), ".eE")
+}
@@ -0,0 +1,118 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiff_Map(t *testing.T) {
+	a := NewMap(map[string]int{"a": 1, "b": 2, "c": 3})
+	b := NewMap(map[string]int{"a": 1, "b": 20, "d": 4})
+
+	changes, err := Diff(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{
+		{Kind: ChangeChanged, Path: "/b", Old: json.Number("2"), New: json.Number("20")},
+		{Kind: ChangeRemoved, Path: "/c", Old: json.Number("3")},
+		{Kind: ChangeAdded, Path: "/d", New: json.Number("4")},
+	}, changes)
+
+	changes, err = Diff(a, a)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestDiff_Nested(t *testing.T) {
+	type Profile struct {
+		Name Ptr[string]       `json:"name"`
+		Tags Slice[string]     `json:"tags"`
+		Meta Map[string, bool] `json:"meta"`
+	}
+	name := "ann"
+	a := Profile{Name: NewPtr(&name), Tags: NewSlice([]string{"x", "y", "z"}), Meta: NewMap(map[string]bool{"a/b": true})}
+	b := Profile{Tags: NewSlice([]string{"x", "w"}), Meta: NewMap(map[string]bool{"a/b": false})}
+
+	changes, err := Diff(a, b)
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{
+		{Kind: ChangeChanged, Path: "/meta/a~1b", Old: true, New: false},
+		{Kind: ChangeChanged, Path: "/name", Old: "ann", New: nil},
+		{Kind: ChangeChanged, Path: "/tags/1", Old: "y", New: "w"},
+		{Kind: ChangeRemoved, Path: "/tags/2", Old: "z"},
+	}, changes)
+}
+
+func TestDiff_NumbersAndTypes(t *testing.T) {
+	changes, err := Diff(map[string]any{"n": 1, "v": []int{1}}, map[string]any{"n": 1.0, "v": map[string]int{}})
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{
+		{Kind: ChangeChanged, Path: "/v", Old: []any{json.Number("1")}, New: map[string]any{}},
+	}, changes, "Equal numbers are not reported and type changes replace the value")
+
+	_, err = Diff(make(chan int), 1)
+	assert.Error(t, err)
+}
+
+func TestDiff_LargeNumbers(t *testing.T) {
+	changes, err := Diff(map[string]int64{"id": 9007199254740993}, map[string]int64{"id": 9007199254740992})
+	assert.NoError(t, err)
+	assert.Equal(t, Changes{
+		{Kind: ChangeChanged, Path: "/id", Old: json.Number("9007199254740993"), New: json.Number("9007199254740992")},
+	}, changes, "Integers above 2^53 must not be rounded")
+
+	tests := []struct {
+		a, b  json.Number
+		equal bool
+	}{
+		{"18446744073709551617", "18446744073709551616", false},
+		{"18446744073709551617", "18446744073709551617.0", true},
+		{"9007199254740993", "9007199254740992.0", false},
+		{"0.30000000000000000001", "0.3", false},
+		{"1e2", "100", true},
+		{"0.10", "0.1", true},
+		{"-0", "0", true},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.equal, jsonNumberEqual(tt.a, tt.b), "%s == %s", tt.a, tt.b)
+	}
+}
+
+func TestDiff_PatchRoundTrip(t *testing.T) {
+	a := NewObject(map[string]any{
+		"name":  "a",
+		"items": []any{1, 2, 3, 4},
+		"owner": map[string]any{"id": 1, "roles": []any{"admin"}},
+	})
+	b := NewObject(map[string]any{
+		"name":  "b",
+		"items": []any{1, 5},
+		"owner": map[string]any{"id": 1, "roles": []any{"admin", "dev"}, "active": true},
+		"extra": nil,
+	})
+
+	changes, err := Diff(a, b)
+	assert.NoError(t, err)
+	patch := changes.Patch()
+	assert.NoError(t, a.ApplyPatch(patch))
+
+	changes, err = Diff(a, b)
+	assert.NoError(t, err)
+	assert.Empty(t, changes)
+}
+
+func TestChanges_Patch(t *testing.T) {
+	changes := Changes{
+		{Kind: ChangeAdded, Path: "/a", New: 1},
+		{Kind: ChangeRemoved, Path: "/b", Old: 2},
+		{Kind: ChangeChanged, Path: "/c", Old: 3, New: nil},
+	}
+	data, err := json.Marshal(changes.Patch())
+	assert.NoError(t, err)
+	assert.JSONEq(t, `[
+		{"op":"add","path":"/a","value":1},
+		{"op":"remove","path":"/b"},
+		{"op":"replace","path":"/c","value":null}
+	]`, string(data))
+}
@@ -0,0 +1,315 @@
+package wrap
+
+import (
+	"context"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// ExpiringMap is a concurrency-safe map with keys of type K and values of type V whose entries expire
+// after a time-to-live (TTL). Expired entries are treated as missing and are removed lazily on access,
+// by DeleteExpired, or by the background janitor started with StartJanitor.
+// The zero value is an empty map ready to use, whose entries never expire unless set with SetWithTTL.
+type ExpiringMap[K comparable, V any] struct {
+	mu       sync.Mutex
+	entries  map[K]expiringEntry[V]
+	ttl      time.Duration
+	onExpire func(K, V)
+	clock    Clock
+	stop     context.CancelFunc
+	done     chan struct{}
+}
+
+// expiringEntry is a value stored in an ExpiringMap along with its TTL and expiration time.
+// A zero expiration time means the entry never expires.
+type expiringEntry[V any] struct {
+	value   V
+	ttl     time.Duration
+	expires time.Time
+}
+
+// ExpiringMapOption configures an ExpiringMap created with NewExpiringMap.
+type ExpiringMapOption[K comparable, V any] func(*ExpiringMap[K, V])
+
+// WithExpireCallback sets a function called with every entry removed because it expired.
+// It runs after the map lock is released, so it may call back into the ExpiringMap.
+func WithExpireCallback[K comparable, V any](onExpire func(K, V)) ExpiringMapOption[K, V] {
+	return func(m *ExpiringMap[K, V]) {
+		m.onExpire = onExpire
+	}
+}
+
+// WithExpiringMapClock sets the Clock used to compute expiration times and to schedule the janitor.
+// It defaults to the system clock.
+func WithExpiringMapClock[K comparable, V any](clock Clock) ExpiringMapOption[K, V] {
+	return func(m *ExpiringMap[K, V]) {
+		m.clock = clock
+	}
+}
+
+// NewExpiringMap creates a new empty ExpiringMap instance whose entries expire after defaultTTL unless set with SetWithTTL.
+// A TTL less than or equal to zero means entries never expire.
+func NewExpiringMap[K comparable, V any](defaultTTL time.Duration, options ...ExpiringMapOption[K, V]) *ExpiringMap[K, V] {
+	m := &ExpiringMap[K, V]{
+		entries: make(map[K]expiringEntry[V]),
+		ttl:     defaultTTL,
+		clock:   systemClock{},
+	}
+	for _, option := range options {
+		option(m)
+	}
+	return m
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists and has not expired.
+func (m *ExpiringMap[K, V]) Get(key K) (V, bool) {
+	m.mu.Lock()
+	entry, ok := m.live(key)
+	expired := m.collect(key)
+	m.mu.Unlock()
+
+	m.notify(expired)
+	return entry.value, ok
+}
+
+// Contains returns true if the key exists and has not expired.
+func (m *ExpiringMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set adds or updates the value for the specified key using the default TTL.
+func (m *ExpiringMap[K, V]) Set(key K, value V) *ExpiringMap[K, V] {
+	return m.SetWithTTL(key, value, m.ttl)
+}
+
+// SetWithTTL adds or updates the value for the specified key, expiring it after ttl.
+// A ttl less than or equal to zero means the entry never expires.
+func (m *ExpiringMap[K, V]) SetWithTTL(key K, value V, ttl time.Duration) *ExpiringMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.entries == nil {
+		m.entries = make(map[K]expiringEntry[V])
+	}
+	m.entries[key] = expiringEntry[V]{value: value, ttl: ttl, expires: m.expiration(ttl)}
+	return m
+}
+
+// Touch extends the lifetime of the entry by its own TTL, counted from now.
+// It returns false if the key does not exist or has already expired.
+func (m *ExpiringMap[K, V]) Touch(key K) bool {
+	return m.refresh(key, func(entry expiringEntry[V]) time.Duration { return entry.ttl })
+}
+
+// Refresh sets a new TTL for the entry, counted from now, and keeps it for subsequent calls to Touch.
+// It returns false if the key does not exist or has already expired.
+func (m *ExpiringMap[K, V]) Refresh(key K, ttl time.Duration) bool {
+	return m.refresh(key, func(expiringEntry[V]) time.Duration { return ttl })
+}
+
+// TTL returns the remaining lifetime of the entry and a boolean indicating if the key exists and has not expired.
+// Entries that never expire report a zero duration.
+func (m *ExpiringMap[K, V]) TTL(key K) (time.Duration, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	entry, ok := m.live(key)
+	if !ok || entry.expires.IsZero() {
+		return 0, ok
+	}
+	return entry.expires.Sub(m.timeSource().Now()), true
+}
+
+// Delete removes the specified key and returns true if it existed and had not expired.
+// Deleted entries are not reported to the expire callback.
+func (m *ExpiringMap[K, V]) Delete(key K) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.live(key)
+	delete(m.entries, key)
+	return ok
+}
+
+// DeleteExpired removes all expired entries, reports them to the expire callback and returns how many were removed.
+func (m *ExpiringMap[K, V]) DeleteExpired() int {
+	m.mu.Lock()
+	var expired []MapEntry[K, V]
+	for key := range m.entries {
+		expired = append(expired, m.collect(key)...)
+	}
+	m.mu.Unlock()
+
+	m.notify(expired)
+	return len(expired)
+}
+
+// Len returns the number of entries that have not expired.
+func (m *ExpiringMap[K, V]) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	count := 0
+	for key := range m.entries {
+		if _, ok := m.live(key); ok {
+			count++
+		}
+	}
+	return count
+}
+
+// IsEmpty returns true if no entry is left that has not expired.
+func (m *ExpiringMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Keys returns the keys of the entries that have not expired, in no particular order.
+func (m *ExpiringMap[K, V]) Keys() []K {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	keys := make([]K, 0, len(m.entries))
+	for key := range m.entries {
+		if _, ok := m.live(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
+
+// ToMap returns a snapshot of the entries that have not expired as a new Map.
+func (m *ExpiringMap[K, V]) ToMap() Map[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	snapshot := make(map[K]V, len(m.entries))
+	for key := range m.entries {
+		if entry, ok := m.live(key); ok {
+			snapshot[key] = entry.value
+		}
+	}
+	return NewMap(snapshot)
+}
+
+// Clear removes all entries without reporting them to the expire callback.
+func (m *ExpiringMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	clear(m.entries)
+}
+
+// StartJanitor starts a background goroutine that calls DeleteExpired every interval, as measured by the map Clock,
+// until ctx is canceled or Close is called. Starting a janitor stops the previous one, if any.
+func (m *ExpiringMap[K, V]) StartJanitor(ctx context.Context, interval time.Duration) {
+	m.Close()
+
+	ctx, stop := context.WithCancel(ctx)
+	done := make(chan struct{})
+
+	m.mu.Lock()
+	m.stop, m.done = stop, done
+	m.mu.Unlock()
+
+	clock := m.timeSource()
+	go func() {
+		defer close(done)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-clock.After(interval):
+				m.DeleteExpired()
+			}
+		}
+	}()
+}
+
+// Close stops the background janitor, if any, and waits for it to exit. The map remains usable.
+func (m *ExpiringMap[K, V]) Close() error {
+	m.mu.Lock()
+	stop, done := m.stop, m.done
+	m.stop, m.done = nil, nil
+	m.mu.Unlock()
+
+	if stop != nil {
+		stop()
+		<-done
+	}
+	return nil
+}
+
+// timeSource returns the Clock of the map, which is the system clock for the zero value.
+func (m *ExpiringMap[K, V]) timeSource() Clock {
+	if m.clock == nil {
+		return systemClock{}
+	}
+	return m.clock
+}
+
+// expiration returns the expiration time for an entry set now with the provided TTL.
+func (m *ExpiringMap[K, V]) expiration(ttl time.Duration) time.Time {
+	if ttl <= 0 {
+		return time.Time{}
+	}
+	return m.timeSource().Now().Add(ttl)
+}
+
+// live returns the entry for the key if it exists and has not expired. The caller must hold the lock.
+func (m *ExpiringMap[K, V]) live(key K) (expiringEntry[V], bool) {
+	entry, ok := m.entries[key]
+	if !ok || (!entry.expires.IsZero() && !m.timeSource().Now().Before(entry.expires)) {
+		return expiringEntry[V]{}, false
+	}
+	return entry, true
+}
+
+// collect removes the entry for the key if it has expired and returns it. The caller must hold the lock.
+func (m *ExpiringMap[K, V]) collect(key K) []MapEntry[K, V] {
+	entry, ok := m.entries[key]
+	if !ok {
+		return nil
+	}
+	if _, alive := m.live(key); alive {
+		return nil
+	}
+	delete(m.entries, key)
+	return []MapEntry[K, V]{{Key: key, Value: entry.value}}
+}
+
+// refresh resets the expiration time of a live entry using the TTL returned by ttl.
+func (m *ExpiringMap[K, V]) refresh(key K, ttl func(expiringEntry[V]) time.Duration) bool {
+	m.mu.Lock()
+	entry, ok := m.live(key)
+	if ok {
+		entry.ttl = ttl(entry)
+		entry.expires = m.expiration(entry.ttl)
+		m.entries[key] = entry
+	}
+	expired := m.collect(key)
+	m.mu.Unlock()
+
+	m.notify(expired)
+	return ok
+}
+
+// notify reports expired entries to the expire callback. The caller must not hold the lock.
+func (m *ExpiringMap[K, V]) notify(expired []MapEntry[K, V]) {
+	if m.onExpire == nil {
+		return
+	}
+	for _, entry := range expired {
+		m.onExpire(entry.Key, entry.Value)
+	}
+}
+
+// cloneReflect returns a deep copy of the ExpiringMap for DeepCopy, with the same entries, expirations and
+// configuration. The janitor is not copied.
+func (m *ExpiringMap[K, V]) cloneReflect(c *cloneState) reflect.Value {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	copied := &ExpiringMap[K, V]{ttl: m.ttl, onExpire: m.onExpire, clock: m.clock}
+	if m.entries != nil {
+		copied.entries = make(map[K]expiringEntry[V], len(m.entries))
+		for key, entry := range m.entries {
+			entry.value = cloneValue(c, entry.value)
+			copied.entries[key] = entry
+		}
+	}
+	return reflect.ValueOf(copied).Elem()
+}
@@ -0,0 +1,178 @@
+package wrap
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExpiringMap_DefaultTTL(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMap(time.Minute, WithExpiringMapClock[string, int](clock))
+
+	m.Set("a", 1)
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	clock.Advance(59 * time.Second)
+	assert.True(t, m.Contains("a"))
+
+	clock.Advance(time.Second)
+	value, ok = m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, 0, value, "An expired entry should return the zero value")
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestExpiringMap_SetWithTTL(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMap(time.Minute, WithExpiringMapClock[string, int](clock))
+
+	m.SetWithTTL("short", 1, time.Second).
+		SetWithTTL("forever", 2, 0).
+		Set("default", 3)
+
+	clock.Advance(2 * time.Second)
+	assert.ElementsMatch(t, []string{"forever", "default"}, m.Keys())
+
+	clock.Advance(time.Hour)
+	assert.Equal(t, map[string]int{"forever": 2}, m.ToMap().X)
+
+	ttl, ok := m.TTL("forever")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl)
+}
+
+func TestExpiringMap_TouchRefresh(t *testing.T) {
+	clock := newFakeClock()
+	m := NewExpiringMap(time.Minute, WithExpiringMapClock[string, int](clock))
+	m.Set("a", 1)
+
+	clock.Advance(50 * time.Second)
+	assert.True(t, m.Touch("a"))
+	ttl, _ := m.TTL("a")
+	assert.Equal(t, time.Minute, ttl)
+
+	assert.True(t, m.Refresh("a", 10*time.Second))
+	clock.Advance(5 * time.Second)
+	assert.True(t, m.Touch("a"), "Touch should reuse the refreshed TTL")
+	ttl, _ = m.TTL("a")
+	assert.Equal(t, 10*time.Second, ttl)
+
+	clock.Advance(10 * time.Second)
+	assert.False(t, m.Touch("a"), "An expired entry cannot be revived")
+	assert.False(t, m.Refresh("a", time.Hour))
+	assert.False(t, m.Touch("missing"))
+}
+
+func TestExpiringMap_OnExpire(t *testing.T) {
+	clock := newFakeClock()
+	var expired []string
+	m := NewExpiringMap(time.Minute,
+		WithExpiringMapClock[string, int](clock),
+		WithExpireCallback(func(key string, value int) { expired = append(expired, key) }),
+	)
+
+	m.Set("a", 1).Set("b", 2).Set("c", 3)
+	m.SetWithTTL("d", 4, time.Hour)
+	assert.True(t, m.Delete("c"))
+
+	clock.Advance(time.Minute)
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+	assert.Equal(t, []string{"a"}, expired, "Get should remove the expired entry")
+
+	assert.Equal(t, 1, m.DeleteExpired())
+	assert.Equal(t, []string{"a", "b"}, expired, "Deleted entries are not reported")
+	assert.Equal(t, 0, m.DeleteExpired())
+	assert.Equal(t, []string{"d"}, m.Keys())
+}
+
+func TestExpiringMap_Janitor(t *testing.T) {
+	clock := newFakeClock()
+	expired := make(chan string, 1)
+	m := NewExpiringMap(time.Minute,
+		WithExpiringMapClock[string, int](clock),
+		WithExpireCallback(func(key string, value int) { expired <- key }),
+	)
+	m.Set("a", 1)
+	m.StartJanitor(context.Background(), time.Second)
+	defer m.Close()
+
+	clock.BlockUntil(1)
+	clock.Advance(30 * time.Second)
+	clock.BlockUntil(1) // the janitor swept nothing and waits for the next interval
+	select {
+	case key := <-expired:
+		t.Fatalf("%s expired too early", key)
+	default:
+	}
+
+	clock.Advance(30 * time.Second)
+	assert.Equal(t, "a", <-expired, "The janitor should remove the expired entry")
+
+	assert.NoError(t, m.Close())
+	assert.NoError(t, m.Close(), "Closing twice should be a no-op")
+}
+
+func TestExpiringMap_JanitorStopsWithContext(t *testing.T) {
+	m := NewExpiringMap(time.Minute, WithExpiringMapClock[string, int](newFakeClock()))
+	ctx, cancel := context.WithCancel(context.Background())
+	m.StartJanitor(ctx, time.Second)
+
+	done := m.done
+	cancel()
+	<-done
+	assert.NoError(t, m.Close())
+}
+
+func TestExpiringMap_ZeroValue(t *testing.T) {
+	var m ExpiringMap[string, int]
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+
+	m.Set("a", 1).SetWithTTL("b", 2, time.Hour)
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	ttl, ok := m.TTL("a")
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), ttl, "The zero value has no default TTL")
+	assert.Equal(t, 2, m.Len())
+}
+
+func TestExpiringMap_Concurrent(t *testing.T) {
+	clock := newFakeClock()
+	var expired sync.WaitGroup
+	expired.Add(1000)
+	m := NewExpiringMap(time.Minute,
+		WithExpiringMapClock[int, int](clock),
+		WithExpireCallback(func(int, int) { expired.Done() }),
+	)
+	m.StartJanitor(context.Background(), time.Second)
+	defer m.Close()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 50; j++ {
+				m.Set(i*50+j, j)
+				m.Get(j)
+				m.Touch(i)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 1000, m.Len())
+	clock.BlockUntil(1)
+	clock.Advance(time.Minute)
+	expired.Wait()
+	assert.True(t, m.IsEmpty())
+}
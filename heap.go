@@ -0,0 +1,238 @@
+package wrap
+
+import (
+	"container/heap"
+	"reflect"
+	"slices"
+)
+
+// HeapHandle identifies a value pushed onto a Heap, so it can later be updated or removed.
+type HeapHandle[T any] struct {
+	value T
+	index int
+}
+
+// Value returns the value the handle refers to.
+func (h *HeapHandle[T]) Value() T {
+	return h.value
+}
+
+// Heap is a generic binary heap (priority queue) of values of type T ordered by a less function:
+// Peek and Pop return the value that is less than all others.
+// A bounded heap (NewTopK) holds at most k values and keeps the k greatest pushed so far, discarding the least.
+type Heap[T any] struct {
+	items   Slice[*HeapHandle[T]]
+	less    func(a, b T) bool
+	bounded bool
+	limit   int
+}
+
+// heapAdapter exposes a Heap through container/heap.Interface without adding those methods to Heap itself.
+type heapAdapter[T any] Heap[T]
+
+func (a *heapAdapter[T]) Len() int {
+	return len(a.items.X)
+}
+
+func (a *heapAdapter[T]) Less(i, j int) bool {
+	return a.less(a.items.X[i].value, a.items.X[j].value)
+}
+
+func (a *heapAdapter[T]) Swap(i, j int) {
+	a.items.X[i], a.items.X[j] = a.items.X[j], a.items.X[i]
+	a.items.X[i].index = i
+	a.items.X[j].index = j
+}
+
+func (a *heapAdapter[T]) Push(x any) {
+	handle := x.(*HeapHandle[T])
+	handle.index = len(a.items.X)
+	a.items.Append(handle)
+}
+
+func (a *heapAdapter[T]) Pop() any {
+	handle, _ := a.items.Pop()
+	handle.index = -1
+	return handle
+}
+
+// NewHeap creates a new empty Heap instance ordered by the provided less function.
+func NewHeap[T any](less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less}
+}
+
+// NewHeapFromSlice creates a new Heap instance holding the values of the provided Slice, built in O(n).
+func NewHeapFromSlice[T any](s Slice[T], less func(a, b T) bool) *Heap[T] {
+	h := &Heap[T]{less: less}
+	h.items.SetCapacity(len(s.X))
+	for i, v := range s.X {
+		h.items.Append(&HeapHandle[T]{value: v, index: i})
+	}
+	heap.Init(h.adapter())
+	return h
+}
+
+// NewTopK creates a new bounded Heap instance holding at most k values. Once full, pushing a value
+// greater than the least held value replaces it, and lesser values are discarded, so the heap keeps
+// the k greatest values according to less.
+func NewTopK[T any](k int, less func(a, b T) bool) *Heap[T] {
+	return &Heap[T]{less: less, bounded: true, limit: max(k, 0)}
+}
+
+// adapter returns the heap as a container/heap.Interface.
+func (h *Heap[T]) adapter() *heapAdapter[T] {
+	return (*heapAdapter[T])(h)
+}
+
+// Push adds a value to the heap in O(log n) and returns its handle.
+// For a full bounded heap it returns nil if the value was discarded.
+func (h *Heap[T]) Push(value T) *HeapHandle[T] {
+	handle := &HeapHandle[T]{value: value}
+	if h.bounded && len(h.items.X) >= h.limit {
+		if h.limit == 0 {
+			return nil
+		}
+		root := h.items.X[0]
+		if !h.less(root.value, value) {
+			return nil
+		}
+		root.index = -1
+		handle.index = 0
+		h.items.X[0] = handle
+		heap.Fix(h.adapter(), 0)
+		return handle
+	}
+	heap.Push(h.adapter(), handle)
+	return handle
+}
+
+// Pop removes and returns the least value from the heap in O(log n), or false if the heap is empty.
+func (h *Heap[T]) Pop() (T, bool) {
+	var zero T
+	if len(h.items.X) == 0 {
+		return zero, false
+	}
+	handle := heap.Pop(h.adapter()).(*HeapHandle[T])
+	return handle.value, true
+}
+
+// Peek returns the least value of the heap without removing it, or false if the heap is empty.
+func (h *Heap[T]) Peek() (T, bool) {
+	var zero T
+	if len(h.items.X) == 0 {
+		return zero, false
+	}
+	return h.items.X[0].value, true
+}
+
+// Len returns the number of values in the heap.
+func (h *Heap[T]) Len() int {
+	return len(h.items.X)
+}
+
+// IsEmpty returns true if the heap is empty, otherwise false.
+func (h *Heap[T]) IsEmpty() bool {
+	return len(h.items.X) == 0
+}
+
+// Clear removes all values from the heap. Existing handles become invalid.
+func (h *Heap[T]) Clear() {
+	for _, handle := range h.items.X {
+		handle.index = -1
+	}
+	h.items.Clear()
+}
+
+// Contains returns true if the handle refers to a value currently in the heap.
+func (h *Heap[T]) Contains(handle *HeapHandle[T]) bool {
+	return handle != nil && handle.index >= 0 && handle.index < len(h.items.X) && h.items.X[handle.index] == handle
+}
+
+// Update replaces the value of the handle and restores the heap order in O(log n).
+// It returns false if the handle is not in the heap.
+func (h *Heap[T]) Update(handle *HeapHandle[T], value T) bool {
+	if !h.Contains(handle) {
+		return false
+	}
+	handle.value = value
+	heap.Fix(h.adapter(), handle.index)
+	return true
+}
+
+// Fix restores the heap order after the value of the handle changed in place, for example through a pointer.
+// It returns false if the handle is not in the heap.
+func (h *Heap[T]) Fix(handle *HeapHandle[T]) bool {
+	if !h.Contains(handle) {
+		return false
+	}
+	heap.Fix(h.adapter(), handle.index)
+	return true
+}
+
+// UpdateAt replaces the value at the specified index of the underlying array and restores the heap order.
+// It returns false if the index is out of bounds.
+func (h *Heap[T]) UpdateAt(index int, value T) bool {
+	handle, ok := h.items.ValueAt(index)
+	if !ok {
+		return false
+	}
+	return h.Update(handle, value)
+}
+
+// FixAt restores the heap order after the value at the specified index of the underlying array changed in place.
+// It returns false if the index is out of bounds.
+func (h *Heap[T]) FixAt(index int) bool {
+	handle, ok := h.items.ValueAt(index)
+	if !ok {
+		return false
+	}
+	return h.Fix(handle)
+}
+
+// Remove removes the value of the handle from the heap in O(log n) and returns it.
+// It returns false if the handle is not in the heap.
+func (h *Heap[T]) Remove(handle *HeapHandle[T]) (T, bool) {
+	var zero T
+	if !h.Contains(handle) {
+		return zero, false
+	}
+	heap.Remove(h.adapter(), handle.index)
+	return handle.value, true
+}
+
+// Values returns a new Slice holding the values of the heap in the order of the underlying array.
+func (h *Heap[T]) Values() Slice[T] {
+	values := make([]T, 0, len(h.items.X))
+	for _, handle := range h.items.X {
+		values = append(values, handle.value)
+	}
+	return NewSlice(values)
+}
+
+// Drain returns a new Slice holding the values of the heap in pop order, without modifying the heap.
+func (h *Heap[T]) Drain() Slice[T] {
+	values := h.Values()
+	slices.SortStableFunc(values.X, func(a, b T) int {
+		switch {
+		case h.less(a, b):
+			return -1
+		case h.less(b, a):
+			return 1
+		}
+		return 0
+	})
+	return values
+}
+
+// cloneReflect returns a deep copy of the Heap for DeepCopy. Its handles are copied too, so a handle held next to
+// the Heap in the same copied value still refers to the copied Heap.
+func (h *Heap[T]) cloneReflect(c *cloneState) reflect.Value {
+	copied := *h
+	copied.items = cloneValue(c, h.items)
+	return reflect.ValueOf(copied)
+}
+
+// cloneReflect returns a deep copy of the HeapHandle for DeepCopy.
+func (h *HeapHandle[T]) cloneReflect(c *cloneState) reflect.Value {
+	return reflect.ValueOf(HeapHandle[T]{value: cloneValue(c, h.value), index: h.index})
+}
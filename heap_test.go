@@ -0,0 +1,144 @@
+package wrap
+
+import (
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func intLess(a, b int) bool {
+	return a < b
+}
+
+func TestHeap_PushPop(t *testing.T) {
+	h := NewHeap(intLess)
+	for _, v := range []int{5, 1, 4, 2, 3} {
+		h.Push(v)
+	}
+	assert.Equal(t, 5, h.Len())
+
+	top, ok := h.Peek()
+	assert.True(t, ok)
+	assert.Equal(t, 1, top)
+
+	popped := []int{}
+	for !h.IsEmpty() {
+		v, _ := h.Pop()
+		popped = append(popped, v)
+	}
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, popped)
+
+	_, ok = h.Pop()
+	assert.False(t, ok)
+	_, ok = h.Peek()
+	assert.False(t, ok)
+}
+
+func TestHeap_FromSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	values := make([]int, 200)
+	for i := range values {
+		values[i] = rng.Intn(1000)
+	}
+
+	h := NewHeapFromSlice(NewSlice(values), intLess)
+	sorted := append([]int(nil), values...)
+	sort.Ints(sorted)
+
+	drained := h.Drain()
+	assert.Equal(t, sorted, drained.X)
+	assert.Equal(t, 200, h.Len(), "Drain should not modify the heap")
+
+	for _, expected := range sorted {
+		v, _ := h.Pop()
+		assert.Equal(t, expected, v)
+	}
+}
+
+func TestHeap_Handles(t *testing.T) {
+	h := NewHeap(intLess)
+	a := h.Push(10)
+	b := h.Push(20)
+	c := h.Push(30)
+
+	assert.True(t, h.Update(c, 5))
+	top, _ := h.Peek()
+	assert.Equal(t, 5, top)
+	assert.Equal(t, 5, c.Value())
+
+	value, ok := h.Remove(a)
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	assert.False(t, h.Contains(a))
+
+	_, ok = h.Remove(a)
+	assert.False(t, ok, "Removing twice should fail")
+	assert.False(t, h.Update(a, 1), "Updating a removed handle should fail")
+
+	drained := h.Drain()
+	assert.Equal(t, []int{5, 20}, drained.X)
+
+	h.Pop()
+	h.Pop()
+	assert.False(t, h.Contains(b), "Popped handles should become invalid")
+}
+
+func TestHeap_FixInPlace(t *testing.T) {
+	type task struct{ priority int }
+	h := NewHeap(func(a, b *task) bool { return a.priority < b.priority })
+	low := &task{priority: 1}
+	high := &task{priority: 2}
+	h.Push(low)
+	handle := h.Push(high)
+
+	high.priority = 0
+	assert.True(t, h.Fix(handle))
+	top, _ := h.Peek()
+	assert.Same(t, high, top)
+
+	low.priority = -1
+	assert.True(t, h.FixAt(1))
+	top, _ = h.Peek()
+	assert.Same(t, low, top)
+
+	assert.False(t, h.FixAt(5))
+	assert.False(t, h.Fix(nil))
+}
+
+func TestHeap_UpdateAt(t *testing.T) {
+	h := NewHeapFromSlice(NewSlice([]int{1, 2, 3}), intLess)
+	assert.True(t, h.UpdateAt(0, 10))
+	assert.False(t, h.UpdateAt(3, 10))
+
+	drained := h.Drain()
+	assert.Equal(t, []int{2, 3, 10}, drained.X)
+}
+
+func TestHeap_TopK(t *testing.T) {
+	h := NewTopK(3, intLess)
+	for _, v := range []int{5, 1, 9, 3, 7, 2, 8} {
+		h.Push(v)
+	}
+	assert.Equal(t, 3, h.Len())
+	drained := h.Drain()
+	assert.Equal(t, []int{7, 8, 9}, drained.X)
+
+	assert.Nil(t, h.Push(0), "Values below the kept ones should be discarded")
+	assert.NotNil(t, h.Push(10))
+
+	none := NewTopK(0, intLess)
+	assert.Nil(t, none.Push(1))
+	assert.True(t, none.IsEmpty())
+}
+
+func TestHeap_Clear(t *testing.T) {
+	h := NewHeap(intLess)
+	handle := h.Push(1)
+	h.Clear()
+	assert.True(t, h.IsEmpty())
+	assert.False(t, h.Contains(handle))
+	values := h.Values()
+	assert.Equal(t, 0, values.Length())
+}
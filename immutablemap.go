@@ -0,0 +1,386 @@
+package wrap
+
+import (
+	"hash/maphash"
+	"math/bits"
+	"reflect"
+	"slices"
+)
+
+const (
+	hamtBits = 5
+	hamtMask = 1<<hamtBits - 1
+)
+
+// immutableMapSeed seeds the key hashes of every ImmutableMap, so that versions can share their tries.
+var immutableMapSeed = maphash.MakeSeed()
+
+// ImmutableMap is a persistent map with keys of type K and values of type V: updates return a new version
+// sharing most of its structure with the original one, which stays unchanged. Changes are recorded in a
+// hash array mapped trie (HAMT) laid over the map the ImmutableMap was converted from.
+// The zero value is an empty map ready to use.
+type ImmutableMap[K comparable, V any] struct {
+	m *persistentMap[K, V]
+}
+
+// persistentMap is a version of an ImmutableMap: the entries of base, overridden by the entries of the trie.
+type persistentMap[K comparable, V any] struct {
+	base map[K]V
+	root *hamtNode[K, V]
+	size int
+}
+
+// hamtNode is a node of a hash array mapped trie. The bitmap tells which hash fragments have a slot.
+// Below the last hash fragment, nodes hold colliding keys in a plain list and have no bitmap.
+type hamtNode[K comparable, V any] struct {
+	edit   *immutableEdit
+	bitmap uint32
+	slots  []hamtSlot[K, V]
+}
+
+// hamtSlot is either an entry or, when child is set, a link to the next level of the trie.
+// A removed entry is a tombstone hiding a key of the base map.
+type hamtSlot[K comparable, V any] struct {
+	hash    uint64
+	key     K
+	value   V
+	removed bool
+	child   *hamtNode[K, V]
+}
+
+// NewImmutableMap creates a new ImmutableMap instance holding the provided entries.
+func NewImmutableMap[K comparable, V any](entries ...MapEntry[K, V]) ImmutableMap[K, V] {
+	b := ImmutableMap[K, V]{}.Builder()
+	for _, entry := range entries {
+		b.Set(entry.Key, entry.Value)
+	}
+	return b.Build()
+}
+
+// ImmutableMapFromMap creates a new ImmutableMap instance from the provided Map in constant time.
+// The ImmutableMap takes ownership of the underlying map, which must not be modified afterwards.
+func ImmutableMapFromMap[K comparable, V any](m Map[K, V]) ImmutableMap[K, V] {
+	if m.X == nil {
+		return ImmutableMap[K, V]{}
+	}
+	return ImmutableMap[K, V]{m: &persistentMap[K, V]{base: m.X, size: len(m.X)}}
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (m ImmutableMap[K, V]) Get(key K) (V, bool) {
+	if m.m == nil {
+		var zero V
+		return zero, false
+	}
+	return m.m.get(key)
+}
+
+// Contains returns true if the specified key exists in the map.
+func (m ImmutableMap[K, V]) Contains(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set returns a new version with the value for the specified key added or updated.
+func (m ImmutableMap[K, V]) Set(key K, value V) ImmutableMap[K, V] {
+	b := m.Builder()
+	b.Set(key, value)
+	return b.Build()
+}
+
+// Delete returns a new version without the specified key.
+func (m ImmutableMap[K, V]) Delete(key K) ImmutableMap[K, V] {
+	b := m.Builder()
+	if !b.Delete(key) {
+		return m
+	}
+	return b.Build()
+}
+
+// Len returns the number of entries in the map.
+func (m ImmutableMap[K, V]) Len() int {
+	if m.m == nil {
+		return 0
+	}
+	return m.m.size
+}
+
+// IsEmpty returns true if the map has no entries.
+func (m ImmutableMap[K, V]) IsEmpty() bool {
+	return m.Len() == 0
+}
+
+// Keys returns the keys of the map, in no particular order.
+func (m ImmutableMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	for key := range m.Iter() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns the values of the map as a new Slice, in no particular order.
+func (m ImmutableMap[K, V]) Values() Slice[V] {
+	values := make([]V, 0, m.Len())
+	for _, value := range m.Iter() {
+		values = append(values, value)
+	}
+	return NewSlice(values)
+}
+
+// Iter returns an iterator over the key-value pairs of the map, in no particular order.
+func (m ImmutableMap[K, V]) Iter() Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.m == nil {
+			return
+		}
+		if !m.m.root.walk(yield) {
+			return
+		}
+		for key, value := range m.m.base {
+			if _, overridden := m.m.root.find(maphash.Comparable(immutableMapSeed, key), key); overridden {
+				continue
+			}
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap returns the entries of the ImmutableMap as a new Map.
+func (m ImmutableMap[K, V]) ToMap() Map[K, V] {
+	if m.m == nil {
+		return Map[K, V]{}
+	}
+	copied := make(map[K]V, m.m.size)
+	for key, value := range m.Iter() {
+		copied[key] = value
+	}
+	return NewMap(copied)
+}
+
+// Builder returns a builder that applies a batch of changes in place and produces a new version with Build.
+// The ImmutableMap itself is left unchanged.
+func (m ImmutableMap[K, V]) Builder() *ImmutableMapBuilder[K, V] {
+	b := &ImmutableMapBuilder[K, V]{edit: &immutableEdit{}}
+	if m.m != nil {
+		b.m = *m.m
+	}
+	return b
+}
+
+// UnmarshalJSON unmarshals JSON data into the ImmutableMap, replacing it with a new version.
+func (m *ImmutableMap[K, V]) UnmarshalJSON(data []byte) error {
+	var decoded Map[K, V]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*m = ImmutableMapFromMap(decoded)
+	return nil
+}
+
+// MarshalJSON marshals the ImmutableMap into JSON, exactly like the equivalent Map.
+func (m ImmutableMap[K, V]) MarshalJSON() ([]byte, error) {
+	return m.ToMap().MarshalJSON()
+}
+
+// get retrieves the value for the key, looking at the trie first and at the base map after.
+func (p *persistentMap[K, V]) get(key K) (V, bool) {
+	if slot, ok := p.root.find(maphash.Comparable(immutableMapSeed, key), key); ok {
+		return slot.value, !slot.removed
+	}
+	value, ok := p.base[key]
+	return value, ok
+}
+
+// ImmutableMapBuilder applies changes to a private copy of an ImmutableMap, modifying in place
+// the parts it already copied. It is not safe for concurrent use.
+type ImmutableMapBuilder[K comparable, V any] struct {
+	edit *immutableEdit
+	m    persistentMap[K, V]
+}
+
+// NewImmutableMapBuilder creates a new builder for an empty ImmutableMap.
+func NewImmutableMapBuilder[K comparable, V any]() *ImmutableMapBuilder[K, V] {
+	return ImmutableMap[K, V]{}.Builder()
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (b *ImmutableMapBuilder[K, V]) Get(key K) (V, bool) {
+	return b.m.get(key)
+}
+
+// Contains returns true if the specified key exists in the builder.
+func (b *ImmutableMapBuilder[K, V]) Contains(key K) bool {
+	_, ok := b.m.get(key)
+	return ok
+}
+
+// Len returns the number of entries in the builder.
+func (b *ImmutableMapBuilder[K, V]) Len() int {
+	return b.m.size
+}
+
+// Set adds or updates the value for the specified key.
+func (b *ImmutableMapBuilder[K, V]) Set(key K, value V) *ImmutableMapBuilder[K, V] {
+	if !b.Contains(key) {
+		b.m.size++
+	}
+	b.m.root = b.put(b.m.root, 0, hamtSlot[K, V]{hash: maphash.Comparable(immutableMapSeed, key), key: key, value: value})
+	return b
+}
+
+// Delete removes the specified key and returns true if it existed.
+func (b *ImmutableMapBuilder[K, V]) Delete(key K) bool {
+	if !b.Contains(key) {
+		return false
+	}
+	hash := maphash.Comparable(immutableMapSeed, key)
+	if _, inBase := b.m.base[key]; inBase {
+		b.m.root = b.put(b.m.root, 0, hamtSlot[K, V]{hash: hash, key: key, removed: true})
+	} else {
+		b.m.root = b.remove(b.m.root, 0, hash, key)
+	}
+	b.m.size--
+	return true
+}
+
+// Build returns a new ImmutableMap holding the current entries. The builder remains usable,
+// and later changes to it do not affect the returned version.
+func (b *ImmutableMapBuilder[K, V]) Build() ImmutableMap[K, V] {
+	b.edit = &immutableEdit{}
+	built := b.m
+	return ImmutableMap[K, V]{m: &built}
+}
+
+// editable returns the node itself if the builder owns it, otherwise an owned copy of it.
+func (b *ImmutableMapBuilder[K, V]) editable(node *hamtNode[K, V]) *hamtNode[K, V] {
+	if node == nil {
+		return &hamtNode[K, V]{edit: b.edit}
+	}
+	if node.edit == b.edit {
+		return node
+	}
+	return &hamtNode[K, V]{edit: b.edit, bitmap: node.bitmap, slots: slices.Clone(node.slots)}
+}
+
+// put adds or replaces the entry under the node at the specified hash shift.
+func (b *ImmutableMapBuilder[K, V]) put(node *hamtNode[K, V], shift uint, entry hamtSlot[K, V]) *hamtNode[K, V] {
+	node = b.editable(node)
+	if shift >= 64 {
+		for i := range node.slots {
+			if node.slots[i].key == entry.key {
+				node.slots[i] = entry
+				return node
+			}
+		}
+		node.slots = append(node.slots, entry)
+		return node
+	}
+
+	bit := uint32(1) << ((entry.hash >> shift) & hamtMask)
+	i := bits.OnesCount32(node.bitmap & (bit - 1))
+	if node.bitmap&bit == 0 {
+		node.slots = slices.Insert(node.slots, i, entry)
+		node.bitmap |= bit
+		return node
+	}
+
+	slot := node.slots[i]
+	switch {
+	case slot.child != nil:
+		node.slots[i].child = b.put(slot.child, shift+hamtBits, entry)
+	case slot.key == entry.key:
+		node.slots[i] = entry
+	default:
+		// Two keys share the hash fragment: move both one level down.
+		child := b.put(nil, shift+hamtBits, slot)
+		node.slots[i] = hamtSlot[K, V]{child: b.put(child, shift+hamtBits, entry)}
+	}
+	return node
+}
+
+// remove deletes the key under the node at the specified hash shift and returns nil if the node becomes empty.
+// The key must be present.
+func (b *ImmutableMapBuilder[K, V]) remove(node *hamtNode[K, V], shift uint, hash uint64, key K) *hamtNode[K, V] {
+	node = b.editable(node)
+	if shift >= 64 {
+		node.slots = slices.DeleteFunc(node.slots, func(slot hamtSlot[K, V]) bool { return slot.key == key })
+	} else {
+		bit := uint32(1) << ((hash >> shift) & hamtMask)
+		i := bits.OnesCount32(node.bitmap & (bit - 1))
+		child := node.slots[i].child
+		if child != nil {
+			child = b.remove(child, shift+hamtBits, hash, key)
+		}
+		switch {
+		case child == nil:
+			node.slots = slices.Delete(node.slots, i, i+1)
+			node.bitmap &^= bit
+		case len(child.slots) == 1 && child.slots[0].child == nil:
+			// A single entry left below moves up, keeping the trie compact.
+			node.slots[i] = child.slots[0]
+		default:
+			node.slots[i].child = child
+		}
+	}
+	if len(node.slots) == 0 {
+		return nil
+	}
+	return node
+}
+
+// find returns the slot holding the key in the trie rooted at the node.
+func (node *hamtNode[K, V]) find(hash uint64, key K) (*hamtSlot[K, V], bool) {
+	for shift := uint(0); node != nil; shift += hamtBits {
+		if shift >= 64 {
+			for i := range node.slots {
+				if node.slots[i].key == key {
+					return &node.slots[i], true
+				}
+			}
+			return nil, false
+		}
+		bit := uint32(1) << ((hash >> shift) & hamtMask)
+		if node.bitmap&bit == 0 {
+			return nil, false
+		}
+		slot := &node.slots[bits.OnesCount32(node.bitmap&(bit-1))]
+		if slot.child == nil {
+			return slot, slot.key == key
+		}
+		node = slot.child
+	}
+	return nil, false
+}
+
+// walk yields the entries of the trie rooted at the node, skipping tombstones, and returns false if yield stopped.
+func (node *hamtNode[K, V]) walk(yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	for _, slot := range node.slots {
+		if slot.child != nil {
+			if !slot.child.walk(yield) {
+				return false
+			}
+		} else if !slot.removed && !yield(slot.key, slot.value) {
+			return false
+		}
+	}
+	return true
+}
+
+// cloneReflect returns a deep copy of the ImmutableMap for DeepCopy. Versions share their values, so the copy
+// is a new version holding copied values.
+func (m *ImmutableMap[K, V]) cloneReflect(c *cloneState) reflect.Value {
+	if m.m == nil {
+		return reflect.ValueOf(ImmutableMap[K, V]{})
+	}
+	entries := m.ToMap()
+	for key, value := range entries.X {
+		entries.X[key] = cloneValue(c, value)
+	}
+	return reflect.ValueOf(ImmutableMapFromMap(entries))
+}
@@ -0,0 +1,170 @@
+package wrap
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutableMap_Persistence(t *testing.T) {
+	v1 := NewImmutableMap(MapEntry[string, int]{Key: "a", Value: 1})
+	v2 := v1.Set("b", 2)
+	v3 := v2.Set("a", 10)
+	v4 := v3.Delete("b")
+
+	assert.Equal(t, map[string]int{"a": 1}, v1.ToMap().X)
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, v2.ToMap().X)
+	assert.Equal(t, map[string]int{"a": 10, "b": 2}, v3.ToMap().X)
+	assert.Equal(t, map[string]int{"a": 10}, v4.ToMap().X)
+	assert.Equal(t, 1, v4.Len())
+
+	value, ok := v3.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	assert.False(t, v4.Contains("b"))
+	assert.Equal(t, v4, v4.Delete("missing"), "Deleting a missing key should return the same version")
+}
+
+func TestImmutableMap_ZeroValue(t *testing.T) {
+	var m ImmutableMap[string, int]
+	assert.True(t, m.IsEmpty())
+	_, ok := m.Get("a")
+	assert.False(t, ok)
+	assert.Empty(t, m.Keys())
+
+	m = m.Set("a", 1)
+	assert.Equal(t, []string{"a"}, m.Keys())
+}
+
+func TestImmutableMap_FromMap(t *testing.T) {
+	base := map[string]int{"a": 1, "b": 2, "c": 3}
+	m := ImmutableMapFromMap(NewMap(base))
+
+	updated := m.Set("a", 10).Set("d", 4).Delete("b").Delete("d")
+	assert.Equal(t, map[string]int{"a": 1, "b": 2, "c": 3}, base, "The adopted map must not be modified")
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, updated.ToMap().X)
+	assert.Equal(t, 2, updated.Len())
+	assert.ElementsMatch(t, []string{"a", "c"}, updated.Keys())
+	values := updated.Values()
+	assert.ElementsMatch(t, []int{10, 3}, values.Unwrap())
+
+	restored := updated.Set("b", 20)
+	value, ok := restored.Get("b")
+	assert.True(t, ok)
+	assert.Equal(t, 20, value)
+	assert.Equal(t, 3, restored.Len())
+}
+
+func TestImmutableMap_Builder(t *testing.T) {
+	b := NewImmutableMapBuilder[int, int]()
+	for i := 0; i < 1000; i++ {
+		b.Set(i, i)
+	}
+	assert.True(t, b.Delete(0))
+	assert.False(t, b.Delete(0))
+	first := b.Build()
+
+	b.Set(1, -1).Set(1000, 1000)
+	b.Delete(2)
+	second := b.Build()
+
+	value, _ := first.Get(1)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 999, first.Len())
+	assert.True(t, first.Contains(2))
+
+	value, _ = second.Get(1)
+	assert.Equal(t, -1, value)
+	assert.Equal(t, 999, second.Len())
+	assert.False(t, second.Contains(2))
+}
+
+func TestImmutableMap_HashCollisions(t *testing.T) {
+	b := NewImmutableMapBuilder[string, int]()
+	// Force full hash collisions to exercise the collision nodes.
+	for i, key := range []string{"a", "b", "c"} {
+		b.m.root = b.put(b.m.root, 0, hamtSlot[string, int]{hash: 42, key: key, value: i})
+	}
+
+	for i, key := range []string{"a", "b", "c"} {
+		slot, ok := b.m.root.find(42, key)
+		assert.True(t, ok)
+		assert.Equal(t, i, slot.value)
+	}
+	_, ok := b.m.root.find(42, "d")
+	assert.False(t, ok)
+
+	b.m.root = b.remove(b.m.root, 0, 42, "b")
+	b.m.root = b.remove(b.m.root, 0, 42, "a")
+	slot, ok := b.m.root.find(42, "c")
+	assert.True(t, ok)
+	assert.Equal(t, 2, slot.value)
+	assert.Nil(t, b.m.root.slots[0].child, "The last colliding entry should move up")
+
+	b.m.root = b.remove(b.m.root, 0, 42, "c")
+	assert.Nil(t, b.m.root)
+}
+
+func TestImmutableMap_MatchesMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	base := map[int]int{}
+	for i := 0; i < 500; i++ {
+		base[i] = i
+	}
+	expected := map[int]int{}
+	for key, value := range base {
+		expected[key] = value
+	}
+	m := ImmutableMapFromMap(NewMap(base))
+	snapshot, snapshotExpected := m, map[int]int{}
+	for key, value := range expected {
+		snapshotExpected[key] = value
+	}
+
+	for step := 0; step < 20000; step++ {
+		key := rng.Intn(2000)
+		if rng.Intn(3) == 0 {
+			delete(expected, key)
+			m = m.Delete(key)
+		} else {
+			expected[key] = step
+			m = m.Set(key, step)
+		}
+		if step == 10000 {
+			snapshot = m
+			snapshotExpected = map[int]int{}
+			for key, value := range expected {
+				snapshotExpected[key] = value
+			}
+		}
+	}
+
+	assert.Equal(t, len(expected), m.Len())
+	assert.Equal(t, expected, m.ToMap().X)
+	assert.Equal(t, snapshotExpected, snapshot.ToMap().X)
+	assert.Len(t, base, 500)
+}
+
+func TestImmutableMap_JSON(t *testing.T) {
+	tests := []Map[string, int]{
+		{},
+		{X: map[string]int{}},
+		{X: map[string]int{"a": 1, "b": 2}},
+	}
+	for _, m := range tests {
+		expected, err := json.Marshal(m)
+		assert.NoError(t, err)
+		data, err := json.Marshal(ImmutableMapFromMap(m))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(data))
+	}
+
+	var decoded ImmutableMap[string, int]
+	err := json.Unmarshal([]byte(`{"x":10}`), &decoded)
+	assert.NoError(t, err)
+	value, ok := decoded.Get("x")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+}
@@ -0,0 +1,458 @@
+package wrap
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+const (
+	vectorBits  = 5
+	vectorWidth = 1 << vectorBits
+	vectorMask  = vectorWidth - 1
+)
+
+// immutableEdit identifies the builder that owns a node of a persistent structure.
+// Nodes owned by a builder may be modified in place until the builder produces a new version.
+type immutableEdit struct {
+	_ byte // ensures every edit token has a distinct address
+}
+
+// ImmutableSlice is a persistent slice of type T: updates return a new version sharing most of its structure
+// with the original one, which stays unchanged. It is a 32-way vector trie, so reads and updates run in
+// effectively constant time. The zero value is an empty slice ready to use.
+type ImmutableSlice[T any] struct {
+	v *persistentVector[T]
+}
+
+// persistentVector is a version of an ImmutableSlice. The last, partially filled leaf is kept apart as the tail.
+// A version converted from a Slice keeps the adopted array in base and only builds its trie on first update.
+type persistentVector[T any] struct {
+	size  int
+	shift uint
+	root  *vectorNode[T]
+	tail  []T
+	base  []T
+	once  sync.Once
+}
+
+// vectorNode is a node of a persistent vector trie. Leaves hold values, other nodes hold children.
+type vectorNode[T any] struct {
+	edit     *immutableEdit
+	children []*vectorNode[T]
+	values   []T
+}
+
+// NewImmutableSlice creates a new ImmutableSlice instance holding a copy of the provided values.
+func NewImmutableSlice[T any](values ...T) ImmutableSlice[T] {
+	return ImmutableSliceFromSlice(NewSlice(slices.Clone(values)))
+}
+
+// ImmutableSliceFromSlice creates a new ImmutableSlice instance from the provided Slice in constant time.
+// The ImmutableSlice takes ownership of the underlying array, which must not be modified afterwards.
+func ImmutableSliceFromSlice[T any](s Slice[T]) ImmutableSlice[T] {
+	if s.X == nil {
+		return ImmutableSlice[T]{}
+	}
+	return ImmutableSlice[T]{v: &persistentVector[T]{size: len(s.X), base: s.X[:len(s.X):len(s.X)]}}
+}
+
+// ValueAt retrieves the value at the specified index and a boolean indicating success.
+func (s ImmutableSlice[T]) ValueAt(index int) (T, bool) {
+	var zero T
+	if index < 0 || index >= s.Length() {
+		return zero, false
+	}
+	if s.v.base != nil {
+		return s.v.base[index], true
+	}
+	return s.v.leafFor(index)[index&vectorMask], true
+}
+
+// Length returns the number of elements in the slice.
+func (s ImmutableSlice[T]) Length() int {
+	if s.v == nil {
+		return 0
+	}
+	return s.v.size
+}
+
+// IsEmpty returns true if the slice has no elements.
+func (s ImmutableSlice[T]) IsEmpty() bool {
+	return s.Length() == 0
+}
+
+// SetValueAt returns a new version with the value at the specified index replaced, and whether the index was valid.
+func (s ImmutableSlice[T]) SetValueAt(index int, value T) (ImmutableSlice[T], bool) {
+	if index < 0 || index >= s.Length() {
+		return s, false
+	}
+	b := s.Builder()
+	b.SetValueAt(index, value)
+	return b.Build(), true
+}
+
+// Append returns a new version with one or more values added to the end of the slice.
+func (s ImmutableSlice[T]) Append(values ...T) ImmutableSlice[T] {
+	if len(values) == 0 {
+		return s
+	}
+	b := s.Builder()
+	b.Append(values...)
+	return b.Build()
+}
+
+// Pop returns a new version without the last value, the removed value, and false if the slice is empty.
+func (s ImmutableSlice[T]) Pop() (ImmutableSlice[T], T, bool) {
+	b := s.Builder()
+	value, ok := b.Pop()
+	if !ok {
+		return s, value, false
+	}
+	return b.Build(), value, true
+}
+
+// RemoveAt returns a new version with a specified number of elements removed starting from the index (default is 1).
+// Removing from the end shares the remaining structure; removing elsewhere rebuilds the following elements.
+func (s ImmutableSlice[T]) RemoveAt(index int, count ...int) ImmutableSlice[T] {
+	n := 1
+	if len(count) > 0 {
+		n = count[0]
+	}
+	size := s.Length()
+	if index < 0 || index >= size || n <= 0 {
+		return s
+	}
+	end := min(index+n, size)
+
+	var rest []T
+	for i := end; i < size; i++ {
+		value, _ := s.ValueAt(i)
+		rest = append(rest, value)
+	}
+	b := s.Builder()
+	for b.Length() > index {
+		b.Pop()
+	}
+	b.Append(rest...)
+	return b.Build()
+}
+
+// Iter returns an iterator over the values of the slice.
+func (s ImmutableSlice[T]) Iter() Seq[T] {
+	return func(yield func(T) bool) {
+		if s.v == nil {
+			return
+		}
+		if s.v.base != nil {
+			for _, value := range s.v.base {
+				if !yield(value) {
+					return
+				}
+			}
+			return
+		}
+		for i := 0; i < s.v.size; i += vectorWidth {
+			for _, value := range s.v.leafFor(i) {
+				if !yield(value) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// ToSlice returns the values of the ImmutableSlice as a new Slice.
+func (s ImmutableSlice[T]) ToSlice() Slice[T] {
+	if s.v == nil {
+		return Slice[T]{}
+	}
+	values := make([]T, 0, s.v.size)
+	for value := range s.Iter() {
+		values = append(values, value)
+	}
+	return NewSlice(values)
+}
+
+// Builder returns a builder that applies a batch of changes in place and produces a new version with Build.
+// The ImmutableSlice itself is left unchanged.
+func (s ImmutableSlice[T]) Builder() *ImmutableSliceBuilder[T] {
+	b := &ImmutableSliceBuilder[T]{edit: &immutableEdit{}, shift: vectorBits, root: &vectorNode[T]{}}
+	if s.v != nil {
+		s.v.index()
+		b.size, b.shift, b.root, b.tail = s.v.size, s.v.shift, s.v.root, s.v.tail
+	}
+	return b
+}
+
+// UnmarshalJSON unmarshals JSON data into the ImmutableSlice, replacing it with a new version.
+func (s *ImmutableSlice[T]) UnmarshalJSON(data []byte) error {
+	var decoded Slice[T]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	*s = ImmutableSliceFromSlice(decoded)
+	return nil
+}
+
+// MarshalJSON marshals the ImmutableSlice into JSON, exactly like the equivalent Slice.
+func (s ImmutableSlice[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice().X)
+}
+
+// index builds the trie of a version converted from a Slice. Leaves share the adopted array.
+func (v *persistentVector[T]) index() {
+	if v.base == nil {
+		return
+	}
+	v.once.Do(func() {
+		b := &ImmutableSliceBuilder[T]{edit: &immutableEdit{}, shift: vectorBits, root: &vectorNode[T]{}}
+		full := len(v.base) - len(v.base)%vectorWidth
+		if full == len(v.base) && full > 0 {
+			full -= vectorWidth
+		}
+		for i := 0; i < full; i += vectorWidth {
+			b.tail = v.base[i : i+vectorWidth : i+vectorWidth]
+			b.size = i + vectorWidth
+			b.pushTail()
+		}
+		v.shift, v.root, v.tail = b.shift, b.root, v.base[full:]
+	})
+}
+
+// leafFor returns the leaf holding the element at the specified index. The version must be indexed.
+func (v *persistentVector[T]) leafFor(index int) []T {
+	return vectorLeafFor(v.size, v.shift, v.root, v.tail, index)
+}
+
+// ImmutableSliceBuilder applies changes to a private copy of an ImmutableSlice, modifying in place
+// the parts it already copied. It is not safe for concurrent use.
+type ImmutableSliceBuilder[T any] struct {
+	edit    *immutableEdit
+	size    int
+	shift   uint
+	root    *vectorNode[T]
+	tail    []T
+	ownTail bool
+}
+
+// NewImmutableSliceBuilder creates a new builder for an empty ImmutableSlice.
+func NewImmutableSliceBuilder[T any]() *ImmutableSliceBuilder[T] {
+	return ImmutableSlice[T]{}.Builder()
+}
+
+// ValueAt retrieves the value at the specified index and a boolean indicating success.
+func (b *ImmutableSliceBuilder[T]) ValueAt(index int) (T, bool) {
+	var zero T
+	if index < 0 || index >= b.size {
+		return zero, false
+	}
+	return vectorLeafFor(b.size, b.shift, b.root, b.tail, index)[index&vectorMask], true
+}
+
+// Length returns the number of elements in the builder.
+func (b *ImmutableSliceBuilder[T]) Length() int {
+	return b.size
+}
+
+// SetValueAt sets the value at the specified index and returns whether the operation was successful.
+func (b *ImmutableSliceBuilder[T]) SetValueAt(index int, value T) bool {
+	if index < 0 || index >= b.size {
+		return false
+	}
+	if index >= b.tailOffset() {
+		b.editTail()
+		b.tail[index&vectorMask] = value
+		return true
+	}
+	b.root = b.setValue(b.shift, b.root, index, value)
+	return true
+}
+
+// Append adds one or more values to the end of the builder.
+func (b *ImmutableSliceBuilder[T]) Append(values ...T) {
+	for _, value := range values {
+		if b.size-b.tailOffset() == vectorWidth {
+			b.pushTail()
+			b.tail, b.ownTail = nil, false
+		}
+		b.editTail()
+		b.tail = append(b.tail, value)
+		b.size++
+	}
+}
+
+// Pop removes and returns the last value, or false if the builder is empty.
+func (b *ImmutableSliceBuilder[T]) Pop() (T, bool) {
+	var zero T
+	if b.size == 0 {
+		return zero, false
+	}
+	value, _ := b.ValueAt(b.size - 1)
+	if b.size-b.tailOffset() > 1 {
+		b.editTail()
+		b.tail[len(b.tail)-1] = zero
+		b.tail = b.tail[:len(b.tail)-1]
+		b.size--
+		return value, true
+	}
+
+	// The tail becomes empty: the last leaf of the trie becomes the new tail.
+	if b.size == 1 {
+		b.tail = nil
+	} else {
+		b.tail = vectorLeafFor(b.size, b.shift, b.root, b.tail, b.size-2)
+		b.root = b.popLeaf(b.shift, b.root)
+		if b.root == nil {
+			b.root = &vectorNode[T]{edit: b.edit}
+		}
+		if b.shift > vectorBits && len(b.root.children) == 1 {
+			b.root = b.root.children[0]
+			b.shift -= vectorBits
+		}
+	}
+	b.ownTail = false
+	b.size--
+	return value, true
+}
+
+// Build returns a new ImmutableSlice holding the current values. The builder remains usable,
+// and later changes to it do not affect the returned version.
+func (b *ImmutableSliceBuilder[T]) Build() ImmutableSlice[T] {
+	if b.size == 0 {
+		return ImmutableSlice[T]{v: &persistentVector[T]{shift: vectorBits, root: &vectorNode[T]{}}}
+	}
+	b.edit = &immutableEdit{}
+	b.ownTail = false
+	return ImmutableSlice[T]{v: &persistentVector[T]{
+		size:  b.size,
+		shift: b.shift,
+		root:  b.root,
+		tail:  b.tail[:len(b.tail):len(b.tail)],
+	}}
+}
+
+// tailOffset returns the index of the first element held by the tail.
+func (b *ImmutableSliceBuilder[T]) tailOffset() int {
+	if b.size < vectorWidth {
+		return 0
+	}
+	return ((b.size - 1) >> vectorBits) << vectorBits
+}
+
+// editTail makes sure the tail is owned by the builder, copying it if necessary.
+func (b *ImmutableSliceBuilder[T]) editTail() {
+	if b.ownTail {
+		return
+	}
+	tail := make([]T, len(b.tail), vectorWidth)
+	copy(tail, b.tail)
+	b.tail, b.ownTail = tail, true
+}
+
+// editable returns the node itself if the builder owns it, otherwise an owned copy of it.
+func (b *ImmutableSliceBuilder[T]) editable(node *vectorNode[T]) *vectorNode[T] {
+	if b.edit != nil && node.edit == b.edit {
+		return node
+	}
+	return &vectorNode[T]{edit: b.edit, children: slices.Clone(node.children), values: slices.Clone(node.values)}
+}
+
+// pushTail moves the full tail into the trie as a new leaf, growing the trie if the root is full.
+func (b *ImmutableSliceBuilder[T]) pushTail() {
+	leaf := &vectorNode[T]{edit: b.edit, values: b.tail[:len(b.tail):len(b.tail)]}
+	if (b.size >> vectorBits) > (1 << b.shift) {
+		b.root = &vectorNode[T]{edit: b.edit, children: []*vectorNode[T]{b.root, b.newPath(b.shift, leaf)}}
+		b.shift += vectorBits
+		return
+	}
+	b.root = b.pushLeaf(b.shift, b.root, leaf)
+}
+
+// pushLeaf inserts the leaf as the last one under the node at the specified level.
+func (b *ImmutableSliceBuilder[T]) pushLeaf(level uint, node, leaf *vectorNode[T]) *vectorNode[T] {
+	node = b.editable(node)
+	i := ((b.size - 1) >> level) & vectorMask
+	if level == vectorBits {
+		node.children = append(node.children, leaf)
+		return node
+	}
+	if i < len(node.children) {
+		node.children[i] = b.pushLeaf(level-vectorBits, node.children[i], leaf)
+	} else {
+		node.children = append(node.children, b.newPath(level-vectorBits, leaf))
+	}
+	return node
+}
+
+// newPath wraps the leaf in as many single-child nodes as needed to reach the specified level.
+func (b *ImmutableSliceBuilder[T]) newPath(level uint, leaf *vectorNode[T]) *vectorNode[T] {
+	if level == 0 {
+		return leaf
+	}
+	return &vectorNode[T]{edit: b.edit, children: []*vectorNode[T]{b.newPath(level-vectorBits, leaf)}}
+}
+
+// popLeaf removes the last leaf under the node at the specified level and returns nil if the node becomes empty.
+func (b *ImmutableSliceBuilder[T]) popLeaf(level uint, node *vectorNode[T]) *vectorNode[T] {
+	i := ((b.size - 2) >> level) & vectorMask
+	if level > vectorBits {
+		child := b.popLeaf(level-vectorBits, node.children[i])
+		if child == nil && i == 0 {
+			return nil
+		}
+		node = b.editable(node)
+		if child == nil {
+			node.children[i] = nil
+			node.children = node.children[:i]
+		} else {
+			node.children[i] = child
+		}
+		return node
+	}
+	if i == 0 {
+		return nil
+	}
+	node = b.editable(node)
+	node.children[i] = nil
+	node.children = node.children[:i]
+	return node
+}
+
+// setValue sets the value at the specified index under the node at the specified level.
+func (b *ImmutableSliceBuilder[T]) setValue(level uint, node *vectorNode[T], index int, value T) *vectorNode[T] {
+	node = b.editable(node)
+	if level == 0 {
+		node.values[index&vectorMask] = value
+		return node
+	}
+	i := (index >> level) & vectorMask
+	node.children[i] = b.setValue(level-vectorBits, node.children[i], index, value)
+	return node
+}
+
+// vectorLeafFor returns the leaf of a vector trie holding the element at the specified index.
+func vectorLeafFor[T any](size int, shift uint, root *vectorNode[T], tail []T, index int) []T {
+	if size < vectorWidth || index >= ((size-1)>>vectorBits)<<vectorBits {
+		return tail
+	}
+	node := root
+	for level := shift; level > 0; level -= vectorBits {
+		node = node.children[(index>>level)&vectorMask]
+	}
+	return node.values
+}
+
+// cloneReflect returns a deep copy of the ImmutableSlice for DeepCopy. Versions share their values, so the copy
+// is a new version holding copied values.
+func (s *ImmutableSlice[T]) cloneReflect(c *cloneState) reflect.Value {
+	if s.v == nil {
+		return reflect.ValueOf(ImmutableSlice[T]{})
+	}
+	values := s.ToSlice()
+	for i, value := range values.X {
+		values.X[i] = cloneValue(c, value)
+	}
+	return reflect.ValueOf(ImmutableSliceFromSlice(values))
+}
@@ -0,0 +1,190 @@
+package wrap
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestImmutableSlice_Persistence(t *testing.T) {
+	v1 := NewImmutableSlice(1, 2, 3)
+	v2 := v1.Append(4, 5)
+	v3, ok := v2.SetValueAt(0, 10)
+	assert.True(t, ok)
+	v4, last, ok := v3.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 5, last)
+
+	assert.Equal(t, []int{1, 2, 3}, v1.ToSlice().X)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, v2.ToSlice().X)
+	assert.Equal(t, []int{10, 2, 3, 4, 5}, v3.ToSlice().X)
+	assert.Equal(t, []int{10, 2, 3, 4}, v4.ToSlice().X)
+
+	_, ok = v1.SetValueAt(3, 0)
+	assert.False(t, ok)
+	_, ok = v1.ValueAt(-1)
+	assert.False(t, ok)
+}
+
+func TestImmutableSlice_ZeroValue(t *testing.T) {
+	var s ImmutableSlice[string]
+	assert.True(t, s.IsEmpty())
+	_, _, ok := s.Pop()
+	assert.False(t, ok)
+
+	s = s.Append("a")
+	value, ok := s.ValueAt(0)
+	assert.True(t, ok)
+	assert.Equal(t, "a", value)
+}
+
+func TestImmutableSlice_BranchesDoNotInterfere(t *testing.T) {
+	base := NewImmutableSlice[int]()
+	for i := 0; i < 40; i++ {
+		base = base.Append(i)
+	}
+	// Both branches append to the same tail.
+	a := base.Append(100)
+	b := base.Append(200)
+
+	value, _ := a.ValueAt(40)
+	assert.Equal(t, 100, value)
+	value, _ = b.ValueAt(40)
+	assert.Equal(t, 200, value)
+	assert.Equal(t, 40, base.Length())
+}
+
+func TestImmutableSlice_FromSlice(t *testing.T) {
+	values := make([]int, 100)
+	for i := range values {
+		values[i] = i
+	}
+	s := ImmutableSliceFromSlice(NewSlice(values))
+	assert.Equal(t, 100, s.Length())
+
+	updated, _ := s.SetValueAt(10, -1)
+	updated = updated.Append(100)
+	updated, _, _ = updated.Pop()
+	updated, _, _ = updated.Pop()
+
+	assert.Equal(t, 10, values[10], "The adopted array must not be modified")
+	assert.Equal(t, 99, values[99])
+	value, _ := updated.ValueAt(10)
+	assert.Equal(t, -1, value)
+	assert.Equal(t, 99, updated.Length())
+	assert.Equal(t, values, s.ToSlice().X)
+}
+
+func TestImmutableSlice_RemoveAt(t *testing.T) {
+	s := NewImmutableSlice(1, 2, 3, 4, 5)
+
+	assert.Equal(t, []int{1, 4, 5}, s.RemoveAt(1, 2).ToSlice().X)
+	assert.Equal(t, []int{1, 2, 3, 4}, s.RemoveAt(4).ToSlice().X)
+	assert.Equal(t, []int{1, 2}, s.RemoveAt(2, 10).ToSlice().X)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s.RemoveAt(5).ToSlice().X)
+	assert.Equal(t, []int{1, 2, 3, 4, 5}, s.ToSlice().X)
+}
+
+func TestImmutableSlice_Builder(t *testing.T) {
+	b := NewImmutableSliceBuilder[int]()
+	for i := 0; i < 2000; i++ {
+		b.Append(i)
+	}
+	b.SetValueAt(0, -1)
+	first := b.Build()
+
+	b.SetValueAt(0, -2)
+	b.Append(2000)
+	second := b.Build()
+
+	value, _ := first.ValueAt(0)
+	assert.Equal(t, -1, value)
+	assert.Equal(t, 2000, first.Length())
+	value, _ = second.ValueAt(0)
+	assert.Equal(t, -2, value)
+	assert.Equal(t, 2001, second.Length())
+}
+
+func TestImmutableSlice_MatchesSlice(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	var expected []int
+	s := ImmutableSliceFromSlice(NewSlice([]int{}))
+	versions := map[int][]int{}
+	snapshots := map[int]ImmutableSlice[int]{}
+
+	for step := 0; step < 20000; step++ {
+		switch op := rng.Intn(10); {
+		case op < 6:
+			value := rng.Int()
+			expected = append(expected, value)
+			s = s.Append(value)
+		case op < 8 && len(expected) > 0:
+			index, value := rng.Intn(len(expected)), rng.Int()
+			expected[index] = value
+			s, _ = s.SetValueAt(index, value)
+		case len(expected) > 0:
+			var popped int
+			s, popped, _ = s.Pop()
+			assert.Equal(t, expected[len(expected)-1], popped)
+			expected = expected[:len(expected)-1]
+		}
+		if step%1000 == 0 {
+			versions[step] = append([]int{}, expected...)
+			snapshots[step] = s
+		}
+	}
+
+	assert.Equal(t, expected, s.ToSlice().X)
+	for step, values := range versions {
+		assert.Equal(t, values, snapshots[step].ToSlice().X, "version %d changed", step)
+	}
+}
+
+func TestImmutableSlice_PopToEmpty(t *testing.T) {
+	s := NewImmutableSlice[int]()
+	for i := 0; i < 1100; i++ {
+		s = s.Append(i)
+	}
+	for i := 1099; i >= 0; i-- {
+		var value int
+		s, value, _ = s.Pop()
+		assert.Equal(t, i, value)
+	}
+	assert.True(t, s.IsEmpty())
+	s = s.Append(1)
+	assert.Equal(t, []int{1}, s.ToSlice().X)
+}
+
+func TestImmutableSlice_Iter(t *testing.T) {
+	s := NewImmutableSlice(1, 2, 3, 4)
+	var collected []int
+	for value := range s.Iter() {
+		if value == 3 {
+			break
+		}
+		collected = append(collected, value)
+	}
+	assert.Equal(t, []int{1, 2}, collected)
+}
+
+func TestImmutableSlice_JSON(t *testing.T) {
+	tests := []Slice[int]{
+		{},
+		{X: []int{}},
+		{X: []int{1, 2, 3}},
+	}
+	for _, s := range tests {
+		expected, err := json.Marshal(s)
+		assert.NoError(t, err)
+		data, err := json.Marshal(ImmutableSliceFromSlice(s))
+		assert.NoError(t, err)
+		assert.Equal(t, string(expected), string(data))
+	}
+
+	var decoded ImmutableSlice[int]
+	err := json.Unmarshal([]byte(`[4,5]`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, decoded.ToSlice().X)
+}
@@ -1,7 +1,17 @@
 package wrap
 
 import (
+	"database/sql/driver"
+	"encoding"
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Map is a generic wrapper for a map with keys of type K and values of type V.
@@ -9,9 +19,6 @@ type Map[K comparable, V any] struct {
 	X map[K]V
 }
 
-// Object is a shortcut for Map[string, any]
-type Object Map[string, any]
-
 // MapEntry represents a key-value pair in the map.
 type MapEntry[K comparable, V any] struct {
 	Key   K
@@ -104,6 +111,32 @@ func (m Map[K, V]) Clear() {
 	}
 }
 
+// Clone returns a deep copy of the Map, where nested slices, maps, pointers and wrappers are copied as well.
+func (m Map[K, V]) Clone() Map[K, V] {
+	return DeepCopy(m)
+}
+
+// Iter returns a lazy sequence over the key-value pairs of the map, in no particular order.
+func (m Map[K, V]) Iter() Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for key, value := range m.X {
+			if !yield(key, value) {
+				return
+			}
+		}
+	}
+}
+
+// KeysIter returns a lazy sequence over the keys of the map, in no particular order.
+func (m Map[K, V]) KeysIter() Seq[K] {
+	return m.Iter().Keys()
+}
+
+// ValuesIter returns a lazy sequence over the values of the map, in no particular order.
+func (m Map[K, V]) ValuesIter() Seq[V] {
+	return m.Iter().Values()
+}
+
 // UnmarshalJSON unmarshals JSON data into the Map. It expects a JSON object representation.
 func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &m.X); err != nil {
@@ -116,3 +149,268 @@ func (m *Map[K, V]) UnmarshalJSON(data []byte) error {
 func (m Map[K, V]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(m.X)
 }
+
+// Scan implements the sql.Scanner interface. It expects a JSON object stored as text or bytes; NULL sets the map to nil.
+func (m *Map[K, V]) Scan(src any) error {
+	m.X = nil
+	if src == nil {
+		return nil
+	}
+	return scanJSON(src, &m.X)
+}
+
+// Value implements the driver.Valuer interface. The map is stored as JSON bytes, or NULL if it is nil.
+func (m Map[K, V]) Value() (driver.Value, error) {
+	if m.X == nil {
+		return nil, nil
+	}
+	return json.Marshal(m.X)
+}
+
+// UnmarshalYAML unmarshals a YAML mapping into the Map.
+func (m *Map[K, V]) UnmarshalYAML(node *yaml.Node) error {
+	return node.Decode(&m.X)
+}
+
+// MarshalYAML marshals the Map into a YAML mapping.
+func (m Map[K, V]) MarshalYAML() (any, error) {
+	return m.X, nil
+}
+
+// UnmarshalXML unmarshals XML data into the Map. It accepts entries in both the key attribute and key/value children layouts.
+func (m *Map[K, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.X = make(map[K]V)
+	return unmarshalXMLEntries(d, func(key K, value V) {
+		m.X[key] = value
+	})
+}
+
+// MarshalXML marshals the Map into XML, writing each entry as <entry key="k">v</entry>. Use XMLMap for other layouts.
+// Entries are sorted by key, or by formatted key when K is not an ordered type, so the output is deterministic.
+// If the map is nil, no element is written.
+func (m Map[K, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLMap(e, start, m.X, XMLKeyAttr{})
+}
+
+// marshalXMLMap writes the entries of the map as children of start using the provided layout, sorted by key or,
+// when K is not an ordered type, by formatted key. If the map is nil, no element is written.
+func marshalXMLMap[K comparable, V any](e *xml.Encoder, start xml.StartElement, m map[K]V, layout XMLMapLayout) error {
+	if m == nil {
+		return nil
+	}
+
+	entries := make([]MapEntry[K, V], 0, len(m))
+	for key, value := range m {
+		entries = append(entries, MapEntry[K, V]{Key: key, Value: value})
+	}
+
+	if isOrderedKind[K]() {
+		compare := orderedComparator[K]()
+		slices.SortFunc(entries, func(a, b MapEntry[K, V]) int {
+			return compare(a.Key, b.Key)
+		})
+		return marshalXMLEntries(e, start, entries, layout)
+	}
+
+	keys := make(map[K]string, len(entries))
+	for _, entry := range entries {
+		formatted, err := formatMapKey(entry.Key)
+		if err != nil {
+			return err
+		}
+		keys[entry.Key] = formatted
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return keys[entries[i].Key] < keys[entries[j].Key]
+	})
+
+	return marshalXMLEntries(e, start, entries, layout)
+}
+
+// marshalXMLEntries writes the entries, in order, as children of start using the provided layout.
+func marshalXMLEntries[K comparable, V any](e *xml.Encoder, start xml.StartElement, entries []MapEntry[K, V], layout XMLMapLayout) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		key, err := formatMapKey(entry.Key)
+		if err != nil {
+			return err
+		}
+
+		element := xml.StartElement{Name: xml.Name{Local: layout.XMLEntryName()}}
+		if !layout.XMLKeyChildren() {
+			element.Attr = []xml.Attr{{Name: xml.Name{Local: "key"}, Value: key}}
+			if err := e.EncodeElement(entry.Value, element); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if err := e.EncodeToken(element); err != nil {
+			return err
+		}
+		if err := e.EncodeElement(key, xml.StartElement{Name: xml.Name{Local: "key"}}); err != nil {
+			return err
+		}
+		if err := e.EncodeElement(entry.Value, xml.StartElement{Name: xml.Name{Local: "value"}}); err != nil {
+			return err
+		}
+		if err := e.EncodeToken(element.End()); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// unmarshalXMLEntries decodes the entry children of the current element, in document order, calling set for each one.
+func unmarshalXMLEntries[K comparable, V any](d *xml.Decoder, set func(K, V)) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			key, value, err := unmarshalXMLEntry[K, V](d, t)
+			if err != nil {
+				return err
+			}
+			set(key, value)
+
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// unmarshalXMLEntry decodes a single entry element in either layout.
+func unmarshalXMLEntry[K comparable, V any](d *xml.Decoder, start xml.StartElement) (K, V, error) {
+	var key K
+	var value V
+
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "key" {
+			key, err := parseMapKey[K](attr.Value)
+			if err != nil {
+				return key, value, err
+			}
+			err = d.DecodeElement(&value, &start)
+			return key, value, err
+		}
+	}
+
+	var hasKey bool
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return key, value, err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "key":
+				var raw string
+				if err := d.DecodeElement(&raw, &t); err != nil {
+					return key, value, err
+				}
+				if key, err = parseMapKey[K](raw); err != nil {
+					return key, value, err
+				}
+				hasKey = true
+			case "value":
+				if err := d.DecodeElement(&value, &t); err != nil {
+					return key, value, err
+				}
+			default:
+				if err := d.Skip(); err != nil {
+					return key, value, err
+				}
+			}
+
+		case xml.EndElement:
+			if !hasKey {
+				return key, value, fmt.Errorf("wrap: XML map entry <%s> has no key", start.Name.Local)
+			}
+			return key, value, nil
+		}
+	}
+}
+
+// formatMapKey converts a map key to its textual form, following the encoding/json rules for object keys.
+func formatMapKey[K comparable](key K) (string, error) {
+	v := reflect.ValueOf(key)
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), nil
+	}
+
+	if marshaler, ok := any(key).(encoding.TextMarshaler); ok {
+		text, err := marshaler.MarshalText()
+		return string(text), err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), nil
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), nil
+	}
+
+	return "", fmt.Errorf("wrap: unsupported map key type %T", key)
+}
+
+// parseMapKey converts the textual form of a map key back into a key of type K.
+func parseMapKey[K comparable](text string) (K, error) {
+	var key K
+	v := reflect.ValueOf(&key).Elem()
+
+	if v.Kind() == reflect.String {
+		v.SetString(text)
+		return key, nil
+	}
+
+	if unmarshaler, ok := any(&key).(encoding.TextUnmarshaler); ok {
+		err := unmarshaler.UnmarshalText([]byte(text))
+		return key, err
+	}
+
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, err := strconv.ParseUint(text, 10, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, v.Type().Bits())
+		if err != nil {
+			return key, err
+		}
+		v.SetFloat(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(text)
+		if err != nil {
+			return key, err
+		}
+		v.SetBool(b)
+	default:
+		return key, fmt.Errorf("wrap: unsupported map key type %T", key)
+	}
+
+	return key, nil
+}
@@ -1,9 +1,11 @@
 package wrap
 
 import (
+	"encoding/xml"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestMap_SetAndGet(t *testing.T) {
@@ -329,4 +331,109 @@ func TestMap_Find(t *testing.T) {
 		assert.Equal(t, tt.expectedKey, key, "Key should be %s", tt.expectedKey)
 		assert.Equal(t, tt.expectedExists, exists, "Existence should be %v", tt.expectedExists)
 	}
-}
\ No newline at end of file
+}
+
+func TestMap_MarshalXML(t *testing.T) {
+	type Test struct {
+		XMLName xml.Name         `xml:"test"`
+		Limits  Map[string, int] `xml:"limits"`
+	}
+
+	tests := []struct {
+		initial  map[string]int
+		expected string
+	}{
+		{
+			initial:  map[string]int{"b": 2, "a": 1},
+			expected: `<test><limits><entry key="a">1</entry><entry key="b">2</entry></limits></test>`,
+		},
+		{
+			initial:  nil,
+			expected: `<test></test>`,
+		},
+	}
+
+	for _, tt := range tests {
+		data, err := xml.Marshal(Test{Limits: NewMap(tt.initial)})
+		assert.NoError(t, err, "Marshalling should not return an error")
+		assert.Equal(t, tt.expected, string(data), "XML output should be %s", tt.expected)
+	}
+}
+
+func TestMap_MarshalXML_OrderedKeys(t *testing.T) {
+	data, err := xml.Marshal(struct {
+		XMLName xml.Name         `xml:"test"`
+		Limits  Map[int, string] `xml:"limits"`
+	}{Limits: NewMap(map[int]string{10: "ten", 2: "two", -1: "minus one"})})
+	assert.NoError(t, err)
+	assert.Equal(t, `<test><limits><entry key="-1">minus one</entry><entry key="2">two</entry><entry key="10">ten</entry></limits></test>`, string(data))
+}
+
+func TestMap_UnmarshalXML(t *testing.T) {
+	type Test struct {
+		Limits Map[int, string] `xml:"limits"`
+	}
+
+	tests := []struct {
+		input    string
+		expected map[int]string
+	}{
+		{
+			input:    `<test><limits><entry key="1">a</entry><entry key="2">b</entry></limits></test>`,
+			expected: map[int]string{1: "a", 2: "b"},
+		},
+		{
+			input:    `<test><limits><entry><key>1</key><value>a</value></entry></limits></test>`,
+			expected: map[int]string{1: "a"},
+		},
+		{
+			input:    `<test><limits></limits></test>`,
+			expected: map[int]string{},
+		},
+	}
+
+	for _, tt := range tests {
+		var test Test
+		err := xml.Unmarshal([]byte(tt.input), &test)
+		assert.NoError(t, err, "Unmarshalling should not return an error")
+		assert.Equal(t, tt.expected, test.Limits.Unwrap(), "Map should be %v after unmarshalling", tt.expected)
+	}
+
+	var test Test
+	err := xml.Unmarshal([]byte(`<test><limits><entry key="x">a</entry></limits></test>`), &test)
+	assert.Error(t, err, "Invalid keys should return an error")
+
+	err = xml.Unmarshal([]byte(`<test><limits><entry><value>a</value></entry></limits></test>`), &test)
+	assert.Error(t, err, "Entries without a key should return an error")
+}
+
+func TestMap_YAML(t *testing.T) {
+	tests := []struct {
+		initial  map[string]int
+		expected string
+	}{
+		{
+			initial:  map[string]int{"b": 2, "a": 1},
+			expected: "limits:\n    a: 1\n    b: 2\n",
+		},
+		{
+			initial:  map[string]int{},
+			expected: "limits: {}\n",
+		},
+	}
+
+	type Test struct {
+		Limits Map[string, int] `yaml:"limits"`
+	}
+
+	for _, tt := range tests {
+		data, err := yaml.Marshal(Test{Limits: NewMap(tt.initial)})
+		assert.NoError(t, err, "Marshalling should not return an error")
+		assert.Equal(t, tt.expected, string(data), "YAML output should be %s", tt.expected)
+
+		var decoded Test
+		err = yaml.Unmarshal(data, &decoded)
+		assert.NoError(t, err, "Unmarshalling should not return an error")
+		assert.Equal(t, tt.initial, decoded.Limits.Unwrap(), "Map should be %v after unmarshalling", tt.initial)
+	}
+}
@@ -0,0 +1,88 @@
+package wrap
+
+import (
+	"maps"
+)
+
+// MapTx is a transaction applying a batch of changes to a Map, which can be committed or rolled back as a whole.
+// Changes are visible in the Map as soon as they are made. A MapTx is not safe for concurrent use, and its
+// mutation methods return ErrTxDone once it is committed or rolled back.
+type MapTx[K comparable, V any] struct {
+	tx
+	m Map[K, V]
+}
+
+// Begin starts a transaction on the Map. If a History is provided, the transaction is recorded into it on commit.
+func (m Map[K, V]) Begin(history ...*History) *MapTx[K, V] {
+	return &MapTx[K, V]{tx: newTx(history), m: m}
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (t *MapTx[K, V]) Get(key K) (V, bool) {
+	return t.m.Get(key)
+}
+
+// Contains checks if the specified key exists in the map.
+func (t *MapTx[K, V]) Contains(key K) bool {
+	return t.m.Contains(key)
+}
+
+// Len returns the number of key-value pairs in the map.
+func (t *MapTx[K, V]) Len() int {
+	return t.m.Len()
+}
+
+// Set adds or updates the value for the specified key.
+func (t *MapTx[K, V]) Set(key K, value V) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	old, existed := t.m.X[key]
+	t.m.X[key] = value
+	t.record(func() {
+		if existed {
+			t.m.X[key] = old
+		} else {
+			delete(t.m.X, key)
+		}
+	}, func() {
+		t.m.X[key] = value
+	})
+	return nil
+}
+
+// Delete removes the key-value pair associated with the specified key.
+func (t *MapTx[K, V]) Delete(key K) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	old, existed := t.m.X[key]
+	if !existed {
+		return nil
+	}
+	delete(t.m.X, key)
+	t.record(func() {
+		t.m.X[key] = old
+	}, func() {
+		delete(t.m.X, key)
+	})
+	return nil
+}
+
+// Clear removes all key-value pairs from the map.
+func (t *MapTx[K, V]) Clear() error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	if len(t.m.X) == 0 {
+		return nil
+	}
+	old := maps.Clone(t.m.X)
+	clear(t.m.X)
+	t.record(func() {
+		maps.Copy(t.m.X, old)
+	}, func() {
+		clear(t.m.X)
+	})
+	return nil
+}
@@ -0,0 +1,73 @@
+package wrap
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMapTx_Rollback(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2})
+
+	tx := m.Begin()
+	assert.NoError(t, tx.Set("a", 10))
+	assert.NoError(t, tx.Set("c", 3))
+	assert.NoError(t, tx.Delete("b"))
+	assert.NoError(t, tx.Delete("missing"))
+	assert.Equal(t, map[string]int{"a": 10, "c": 3}, m.X, "Changes are visible before commit")
+	value, ok := tx.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+	assert.Equal(t, 2, tx.Len())
+
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.X)
+}
+
+func TestMapTx_Commit(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1})
+
+	tx := m.Begin()
+	tx.Set("b", 2)
+	assert.True(t, tx.Contains("b"))
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.X)
+}
+
+func TestMapTx_Clear(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2})
+	history := NewHistory(0)
+
+	tx := m.Begin(history)
+	tx.Set("c", 3)
+	tx.Clear()
+	tx.Set("d", 4)
+	assert.Equal(t, map[string]int{"d": 4}, m.X)
+	assert.NoError(t, tx.Commit())
+
+	history.Undo()
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, m.X)
+	history.Redo()
+	assert.Equal(t, map[string]int{"d": 4}, m.X)
+}
+
+func TestMapTx_AllOrNothing(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1})
+	apply := func(updates map[string]int) error {
+		tx := m.Begin()
+		for key, value := range updates {
+			if value < 0 {
+				tx.Rollback()
+				return errors.New("negative value")
+			}
+			tx.Set(key, value)
+		}
+		return tx.Commit()
+	}
+
+	assert.Error(t, apply(map[string]int{"a": 5, "b": 6, "c": -1}))
+	assert.Equal(t, map[string]int{"a": 1}, m.X)
+	assert.NoError(t, apply(map[string]int{"a": 5, "b": 6}))
+	assert.Equal(t, map[string]int{"a": 5, "b": 6}, m.X)
+}
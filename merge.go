@@ -0,0 +1,380 @@
+package wrap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// MergeStrategy tells Merge how to combine a value of the destination with the value of the source.
+type MergeStrategy int
+
+const (
+	// MergeOverride replaces the destination value with the source value. It is the default for non-map values.
+	MergeOverride MergeStrategy = iota
+	// MergeKeepExisting keeps the destination value, using the source value only when the destination has none.
+	MergeKeepExisting
+	// MergeAppend appends the source elements to the destination slice.
+	MergeAppend
+	// MergeAppendUnique appends the source elements that are not already in the destination slice.
+	MergeAppendUnique
+	// MergeRecurse merges maps key by key, recursing into nested maps. It is the default for maps.
+	MergeRecurse
+)
+
+// String returns the name of the strategy.
+func (s MergeStrategy) String() string {
+	switch s {
+	case MergeOverride:
+		return "override"
+	case MergeKeepExisting:
+		return "keep-existing"
+	case MergeAppend:
+		return "append"
+	case MergeAppendUnique:
+		return "append-unique"
+	case MergeRecurse:
+		return "recurse"
+	}
+	return fmt.Sprintf("MergeStrategy(%d)", int(s))
+}
+
+// MergeOption configures a call to Merge.
+type MergeOption func(*mergeOptions)
+
+// mergeOptions holds the configuration of a call to Merge.
+type mergeOptions struct {
+	types      map[reflect.Type]MergeStrategy
+	paths      map[string]MergeStrategy
+	onConflict func(path string, dst, src any) (any, error)
+	nilPtrs    bool
+}
+
+// WithMergeStrategy sets the strategy used for source values of type T, such as Slice[string] or []any.
+func WithMergeStrategy[T any](strategy MergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.types[reflect.TypeFor[T]()] = strategy
+	}
+}
+
+// WithMergePathStrategy sets the strategy used for the value at the provided path, made of the map keys leading
+// to it joined by dots, such as "server.ports". It takes precedence over the strategies set by type.
+func WithMergePathStrategy(path string, strategy MergeStrategy) MergeOption {
+	return func(o *mergeOptions) {
+		o.paths[path] = strategy
+	}
+}
+
+// WithMergeConflict sets a function resolving conflicts: values present on both sides, different from each other,
+// and not merged by MergeRecurse, MergeAppend or MergeAppendUnique. It returns the value to keep, or an error that
+// aborts the merge.
+func WithMergeConflict(onConflict func(path string, dst, src any) (any, error)) MergeOption {
+	return func(o *mergeOptions) {
+		o.onConflict = onConflict
+	}
+}
+
+// WithMergeNilPtrs makes nil Ptr and pointer values of the source override the destination. By default they are
+// skipped, so that unset fields of a source layer leave the destination unchanged.
+func WithMergeNilPtrs() MergeOption {
+	return func(o *mergeOptions) {
+		o.nilPtrs = true
+	}
+}
+
+// Merge deeply merges src into the value pointed to by dst. It works on Map, Object, Slice, Ptr and Set values and
+// on plain maps, slices and pointers, including the ones nested inside them. By default nested maps are merged key
+// by key, other values of src override the ones of dst and nil pointers of src are skipped. Values taken from src
+// are copied, so the result does not share maps or slices with src. If an error occurs, dst is left unchanged.
+func Merge(dst, src any, options ...MergeOption) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() {
+		return fmt.Errorf("wrap: cannot merge into non-pointer %T", dst)
+	}
+	o := &mergeOptions{types: map[reflect.Type]MergeStrategy{}, paths: map[string]MergeStrategy{}}
+	for _, option := range options {
+		option(o)
+	}
+
+	source := reflect.ValueOf(src)
+	if source.Kind() == reflect.Pointer && source.Type() == target.Type() {
+		source = source.Elem()
+	}
+	merged, err := o.merge("", target.Elem().Type(), target.Elem(), source)
+	if err != nil {
+		return err
+	}
+	target.Elem().Set(merged)
+	return nil
+}
+
+// merge returns the result of merging src into dst, to be stored in a slot of type typ. An invalid dst stands
+// for a missing value.
+func (o *mergeOptions) merge(path string, typ reflect.Type, dst, src reflect.Value) (reflect.Value, error) {
+	dst, src = concreteValue(dst), concreteValue(src)
+	if !o.nilPtrs && isNilPointer(src) {
+		if dst.IsValid() {
+			return dst, nil
+		}
+		return reflect.Zero(typ), nil
+	}
+
+	strategy := MergeOverride
+	if src.IsValid() && isMapLike(src) {
+		strategy = MergeRecurse
+	}
+	if src.IsValid() {
+		if s, ok := o.types[src.Type()]; ok {
+			strategy = s
+		}
+	}
+	if s, ok := o.paths[path]; ok {
+		strategy = s
+	}
+
+	var result reflect.Value
+	var err error
+	switch strategy {
+	case MergeRecurse:
+		if !src.IsValid() || !isMapLike(src) {
+			return reflect.Value{}, mergeStrategyError(path, strategy, src)
+		}
+		if !dst.IsValid() || !isMapLike(dst) {
+			dst = reflect.Value{}
+		}
+		result, err = o.mergeMaps(path, typ, dst, src)
+	case MergeAppend, MergeAppendUnique:
+		if !src.IsValid() || !isSliceLike(src) {
+			return reflect.Value{}, mergeStrategyError(path, strategy, src)
+		}
+		if !dst.IsValid() || !isSliceLike(dst) {
+			dst = reflect.Value{}
+		}
+		result, err = mergeSlices(path, typ, dst, src, strategy == MergeAppendUnique)
+	case MergeOverride, MergeKeepExisting:
+		result, err = o.resolve(path, strategy, dst, src)
+	default:
+		return reflect.Value{}, mergeStrategyError(path, strategy, src)
+	}
+	if err != nil {
+		return reflect.Value{}, err
+	}
+	return assignableTo(path, typ, result)
+}
+
+// resolve picks the destination or the source value, asking the conflict function when both are present and differ.
+func (o *mergeOptions) resolve(path string, strategy MergeStrategy, dst, src reflect.Value) (reflect.Value, error) {
+	present := dst.IsValid() && !isNilPointer(dst)
+	if present && src.IsValid() && o.onConflict != nil && !reflect.DeepEqual(dst.Interface(), src.Interface()) {
+		resolved, err := o.onConflict(path, dst.Interface(), src.Interface())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		return reflect.ValueOf(resolved), nil
+	}
+	if strategy == MergeKeepExisting && present {
+		return dst, nil
+	}
+	return copyMergeValue(src), nil
+}
+
+// mergeMaps returns a new map holding the entries of dst merged with the ones of src.
+func (o *mergeOptions) mergeMaps(path string, typ reflect.Type, dst, src reflect.Value) (reflect.Value, error) {
+	resultType := mergeResultType(typ, dst, src)
+	dstMap, srcMap := unwrapValue(dst), unwrapValue(src)
+	mapType := unwrapValue(reflect.Zero(resultType)).Type()
+
+	merged := reflect.MakeMapWithSize(mapType, srcMap.Len())
+	if dstMap.IsValid() {
+		for iter := dstMap.MapRange(); iter.Next(); {
+			merged.SetMapIndex(iter.Key(), iter.Value())
+		}
+	}
+	for iter := srcMap.MapRange(); iter.Next(); {
+		key, err := assignableTo(path, mapType.Key(), iter.Key())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		child := fmt.Sprint(key.Interface())
+		if path != "" {
+			child = path + "." + child
+		}
+		value, err := o.merge(child, mapType.Elem(), merged.MapIndex(key), iter.Value())
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		merged.SetMapIndex(key, value)
+	}
+	return wrapValue(resultType, merged), nil
+}
+
+// mergeSlices returns a new slice holding the elements of dst followed by the ones of src. With unique set,
+// elements already present are skipped.
+func mergeSlices(path string, typ reflect.Type, dst, src reflect.Value, unique bool) (reflect.Value, error) {
+	resultType := mergeResultType(typ, dst, src)
+	dstSlice, srcSlice := unwrapValue(dst), unwrapValue(src)
+	sliceType := unwrapValue(reflect.Zero(resultType)).Type()
+
+	merged := reflect.MakeSlice(sliceType, 0, srcSlice.Len())
+	if dstSlice.IsValid() {
+		merged = reflect.AppendSlice(merged, dstSlice)
+	}
+	for i := 0; i < srcSlice.Len(); i++ {
+		element, err := assignableTo(fmt.Sprintf("%s[%d]", path, i), sliceType.Elem(), copyMergeValue(srcSlice.Index(i)))
+		if err != nil {
+			return reflect.Value{}, err
+		}
+		if unique && containsMergeValue(merged, element) {
+			continue
+		}
+		merged = reflect.Append(merged, element)
+	}
+	return wrapValue(resultType, merged), nil
+}
+
+// mergeResultType returns the type of a merged map or slice: the one of dst if present, the one of the slot if
+// concrete, or else the one of src.
+func mergeResultType(typ reflect.Type, dst, src reflect.Value) reflect.Type {
+	switch {
+	case dst.IsValid():
+		return dst.Type()
+	case typ.Kind() != reflect.Interface:
+		return typ
+	}
+	return src.Type()
+}
+
+// copyMergeValue returns a copy of a value taken from the source, so that the result shares no maps, slices or
+// pointers with it. Other values are returned as is.
+func copyMergeValue(value reflect.Value) reflect.Value {
+	value = concreteValue(value)
+	if !value.IsValid() {
+		return value
+	}
+	inner := unwrapValue(value)
+	var copied reflect.Value
+	switch inner.Kind() {
+	case reflect.Map:
+		if inner.IsNil() {
+			return value
+		}
+		copied = reflect.MakeMapWithSize(inner.Type(), inner.Len())
+		for iter := inner.MapRange(); iter.Next(); {
+			element := copyMergeValue(iter.Value())
+			if !element.IsValid() {
+				element = reflect.Zero(inner.Type().Elem())
+			}
+			copied.SetMapIndex(iter.Key(), element)
+		}
+	case reflect.Slice:
+		if inner.IsNil() {
+			return value
+		}
+		copied = reflect.MakeSlice(inner.Type(), inner.Len(), inner.Len())
+		for i := 0; i < inner.Len(); i++ {
+			if element := copyMergeValue(inner.Index(i)); element.IsValid() {
+				copied.Index(i).Set(element)
+			}
+		}
+	case reflect.Pointer:
+		if inner.IsNil() {
+			return value
+		}
+		copied = reflect.New(inner.Type().Elem())
+		if element := copyMergeValue(inner.Elem()); element.IsValid() {
+			copied.Elem().Set(element)
+		}
+	default:
+		return value
+	}
+	return wrapValue(value.Type(), copied)
+}
+
+// containsMergeValue returns true if the slice holds an element deeply equal to the value.
+func containsMergeValue(slice, value reflect.Value) bool {
+	for i := 0; i < slice.Len(); i++ {
+		if reflect.DeepEqual(slice.Index(i).Interface(), value.Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+// assignableTo returns the value ready to be stored in a slot of type typ, or an error if its type is not compatible.
+func assignableTo(path string, typ reflect.Type, value reflect.Value) (reflect.Value, error) {
+	if !value.IsValid() {
+		return reflect.Zero(typ), nil
+	}
+	if !value.Type().AssignableTo(typ) {
+		inner, slot := unwrapValue(value), unwrapValue(reflect.Zero(typ))
+		if (inner != value || slot.Type() != typ) && inner.Type().AssignableTo(slot.Type()) {
+			return wrapValue(typ, inner), nil
+		}
+		return reflect.Value{}, fmt.Errorf("wrap: cannot merge %q: %s is not assignable to %s", path, value.Type(), typ)
+	}
+	if typ.Kind() != reflect.Interface {
+		return value, nil
+	}
+	slot := reflect.New(typ).Elem()
+	slot.Set(value)
+	return slot, nil
+}
+
+// mergeStrategyError returns the error reported when a strategy does not apply to a value.
+func mergeStrategyError(path string, strategy MergeStrategy, value reflect.Value) error {
+	kind := "null"
+	if value.IsValid() {
+		kind = value.Type().String()
+	}
+	return fmt.Errorf("wrap: cannot merge %q: strategy %s does not apply to %s", path, strategy, kind)
+}
+
+// concreteValue returns the value held by an interface, or an invalid value for a nil interface.
+func concreteValue(value reflect.Value) reflect.Value {
+	for value.IsValid() && value.Kind() == reflect.Interface {
+		value = value.Elem()
+	}
+	return value
+}
+
+// isWrapper returns true if the type is one of the wrappers of this package holding their value in the X field.
+func isWrapper(typ reflect.Type) bool {
+	return typ.Kind() == reflect.Struct && typ.PkgPath() == wrapPkgPath &&
+		typ.NumField() == 1 && typ.Field(0).Name == "X"
+}
+
+// wrapPkgPath is the import path of this package.
+var wrapPkgPath = reflect.TypeFor[Ptr[int]]().PkgPath()
+
+// unwrapValue returns the X field of a wrapper, or the value itself.
+func unwrapValue(value reflect.Value) reflect.Value {
+	if value.IsValid() && isWrapper(value.Type()) {
+		return value.Field(0)
+	}
+	return value
+}
+
+// wrapValue returns the inner value wrapped into a value of type typ if it is a wrapper, or the inner value itself.
+func wrapValue(typ reflect.Type, inner reflect.Value) reflect.Value {
+	if !isWrapper(typ) {
+		return inner
+	}
+	wrapped := reflect.New(typ).Elem()
+	wrapped.Field(0).Set(inner)
+	return wrapped
+}
+
+// isMapLike returns true if the value is a map or a wrapper of a map.
+func isMapLike(value reflect.Value) bool {
+	return unwrapValue(value).Kind() == reflect.Map
+}
+
+// isSliceLike returns true if the value is a slice or a wrapper of a slice.
+func isSliceLike(value reflect.Value) bool {
+	return unwrapValue(value).Kind() == reflect.Slice
+}
+
+// isNilPointer returns true if the value is a nil pointer or a Ptr wrapping one.
+func isNilPointer(value reflect.Value) bool {
+	inner := unwrapValue(value)
+	return inner.IsValid() && inner.Kind() == reflect.Pointer && inner.IsNil()
+}
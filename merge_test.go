@@ -0,0 +1,193 @@
+package wrap
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMerge_NestedMaps(t *testing.T) {
+	dst := map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"host": "localhost",
+			"port": 8080,
+		},
+	}
+	src := map[string]any{
+		"server": map[string]any{
+			"port": 9090,
+			"tls":  true,
+		},
+		"debug": true,
+	}
+
+	err := Merge(&dst, src)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"name":  "app",
+		"debug": true,
+		"server": map[string]any{
+			"host": "localhost",
+			"port": 9090,
+			"tls":  true,
+		},
+	}, dst)
+
+	// The result shares no maps with the source.
+	dst["server"].(map[string]any)["host"] = "example.com"
+	_, ok := src["server"].(map[string]any)["host"]
+	assert.False(t, ok)
+}
+
+func TestMerge_Layers(t *testing.T) {
+	defaults := NewObject(map[string]any{"log": map[string]any{"level": "info", "format": "text"}})
+	file := NewMap(map[string]any{"log": NewMap(map[string]any{"format": "json"})})
+	env := map[string]any{"log": map[string]any{"level": "debug"}}
+
+	config := NewObject(nil)
+	for _, layer := range []any{defaults, file, env} {
+		assert.NoError(t, Merge(&config, layer))
+	}
+	level, _ := config.Get("log.level")
+	format, _ := config.Get("log.format")
+	assert.Equal(t, "debug", level)
+	assert.Equal(t, "json", format)
+
+	// Defaults are left untouched.
+	level, _ = defaults.Get("log.level")
+	assert.Equal(t, "info", level)
+}
+
+func TestMerge_Strategies(t *testing.T) {
+	tests := []struct {
+		name     string
+		options  []MergeOption
+		expected map[string]any
+	}{
+		{
+			name:     "override",
+			expected: map[string]any{"tags": []any{"b", "c"}, "port": 2},
+		},
+		{
+			name:     "keep existing",
+			options:  []MergeOption{WithMergeStrategy[int](MergeKeepExisting)},
+			expected: map[string]any{"tags": []any{"b", "c"}, "port": 1},
+		},
+		{
+			name:     "append",
+			options:  []MergeOption{WithMergeStrategy[[]any](MergeAppend)},
+			expected: map[string]any{"tags": []any{"a", "b", "b", "c"}, "port": 2},
+		},
+		{
+			name:     "append unique",
+			options:  []MergeOption{WithMergeStrategy[[]any](MergeAppendUnique)},
+			expected: map[string]any{"tags": []any{"a", "b", "c"}, "port": 2},
+		},
+		{
+			name:     "path",
+			options:  []MergeOption{WithMergeStrategy[[]any](MergeAppend), WithMergePathStrategy("tags", MergeKeepExisting)},
+			expected: map[string]any{"tags": []any{"a", "b"}, "port": 2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dst := map[string]any{"tags": []any{"a", "b"}, "port": 1}
+			src := map[string]any{"tags": []any{"b", "c"}, "port": 2}
+			assert.NoError(t, Merge(&dst, src, tt.options...))
+			assert.Equal(t, tt.expected, dst)
+		})
+	}
+}
+
+func TestMerge_Wrappers(t *testing.T) {
+	type layer = Map[string, Slice[string]]
+	dst := NewMap(map[string]Slice[string]{"hosts": NewSlice([]string{"a"})})
+	src := NewMap(map[string]Slice[string]{"hosts": NewSlice([]string{"a", "b"}), "peers": NewSlice([]string{"c"})})
+
+	err := Merge(&dst, src, WithMergeStrategy[Slice[string]](MergeAppendUnique))
+	assert.NoError(t, err)
+	assert.Equal(t, layer{X: map[string]Slice[string]{
+		"hosts": NewSlice([]string{"a", "b"}),
+		"peers": NewSlice([]string{"c"}),
+	}}, dst)
+
+	// Changing the merged slices does not change the source.
+	dst.X["peers"].X[0] = "changed"
+	assert.Equal(t, "c", src.X["peers"].X[0])
+
+	set := NewSet(1, 2)
+	assert.NoError(t, Merge(&set, NewSet(3)))
+	assert.Equal(t, NewSet(1, 2, 3), set)
+}
+
+func TestMerge_NilPtr(t *testing.T) {
+	port, timeout := 8080, 30
+	dst := map[string]Ptr[int]{"port": NewPtr(&port), "timeout": NewPtr(&port)}
+	src := map[string]Ptr[int]{"port": NewNilPtr[int](), "timeout": NewPtr(&timeout)}
+
+	assert.NoError(t, Merge(&dst, src))
+	assert.Equal(t, 8080, *dst["port"].X)
+	assert.Equal(t, 30, *dst["timeout"].X)
+	assert.NotSame(t, src["timeout"].X, dst["timeout"].X)
+
+	assert.NoError(t, Merge(&dst, src, WithMergeNilPtrs()))
+	assert.Nil(t, dst["port"].X)
+
+	name := "app"
+	target := NewPtr(&name)
+	assert.NoError(t, Merge(&target, (*string)(nil)))
+	assert.Equal(t, "app", *target.X)
+}
+
+func TestMerge_PtrToReferences(t *testing.T) {
+	src := map[string]Ptr[[]int]{"a": NewPtr(&[]int{1, 2})}
+	dst := map[string]Ptr[[]int]{}
+	assert.NoError(t, Merge(&dst, src))
+	(*dst["a"].X)[0] = 42
+	assert.Equal(t, []int{1, 2}, *src["a"].X, "Changing the merged slice does not change the source")
+
+	limits := map[string]int{"cpu": 1}
+	srcMap := map[string]any{"limits": NewPtr(&limits)}
+	dstMap := map[string]any{}
+	assert.NoError(t, Merge(&dstMap, srcMap))
+	(*dstMap["limits"].(Ptr[map[string]int]).X)["cpu"] = 42
+	assert.Equal(t, map[string]int{"cpu": 1}, limits, "Changing the merged map does not change the source")
+}
+
+func TestMerge_Conflict(t *testing.T) {
+	dst := map[string]any{"a": 1, "b": map[string]any{"c": "x", "d": 1}}
+	src := map[string]any{"a": 1, "b": map[string]any{"c": "y", "e": 2}}
+
+	var conflicts []string
+	err := Merge(&dst, src, WithMergeConflict(func(path string, old, new any) (any, error) {
+		conflicts = append(conflicts, fmt.Sprintf("%s:%v:%v", path, old, new))
+		return old, nil
+	}))
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b.c:x:y"}, conflicts)
+	assert.Equal(t, map[string]any{"a": 1, "b": map[string]any{"c": "x", "d": 1, "e": 2}}, dst)
+
+	failure := errors.New("conflict")
+	before := fmt.Sprint(dst)
+	err = Merge(&dst, map[string]any{"d": 0, "b": map[string]any{"c": "z"}}, WithMergeConflict(func(string, any, any) (any, error) {
+		return nil, failure
+	}))
+	assert.ErrorIs(t, err, failure)
+	assert.Equal(t, before, fmt.Sprint(dst))
+}
+
+func TestMerge_Errors(t *testing.T) {
+	dst := map[string]int{"a": 1}
+	assert.Error(t, Merge(dst, map[string]int{}))
+	assert.Error(t, Merge(&dst, map[string]string{"a": "x"}))
+	assert.Error(t, Merge(&dst, map[string]int{"a": 2}, WithMergePathStrategy("a", MergeAppend)))
+	assert.Equal(t, map[string]int{"a": 1}, dst)
+
+	var values []any
+	assert.NoError(t, Merge(&values, NewSlice([]any{1, 2})))
+	assert.Equal(t, []any{1, 2}, values)
+}
@@ -0,0 +1,181 @@
+package wrap
+
+import (
+	"fmt"
+	"reflect"
+)
+
+// optionalField is implemented by Optional, letting reflection-based helpers read its state.
+type optionalField interface {
+	optionalState() (set, null bool, value reflect.Value)
+}
+
+// reflectValueSetter is implemented by the wrappers that reflection-based helpers can set from a value of their
+// element type. An invalid value stands for null.
+type reflectValueSetter interface {
+	setReflectValue(value reflect.Value) bool
+}
+
+// pointerWrapper is implemented by Ptr, letting reflection-based helpers read its pointer.
+type pointerWrapper interface {
+	reflectPointer() reflect.Value
+}
+
+// MergePatch applies a merge patch (RFC 7396) held in the patch struct onto the struct pointed to by dst.
+// Fields are matched by name. Optional fields of the patch are skipped when absent, clear the target field when
+// null and set it otherwise. Other fields are skipped when they hold their zero value, since it cannot be told
+// apart from an absent one. Nested patch structs of a different type than their target are merged recursively,
+// like JSON objects; any other value replaces the target field, which may be a T, *T, Ptr[T] or Optional[T].
+// The patch is applied to a copy of the target, which is stored into dst only on success: on error dst is left
+// unchanged.
+func MergePatch(dst, patch any) error {
+	target := reflect.ValueOf(dst)
+	if target.Kind() != reflect.Pointer || target.IsNil() || target.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("wrap: cannot merge patch into %T: not a pointer to a struct", dst)
+	}
+	source := reflect.Indirect(reflect.ValueOf(patch))
+	if source.Kind() != reflect.Struct {
+		return fmt.Errorf("wrap: cannot merge patch %T: not a struct", patch)
+	}
+	merged := reflect.New(target.Elem().Type()).Elem()
+	merged.Set(target.Elem())
+	if err := mergePatchStruct(merged, source, ""); err != nil {
+		return err
+	}
+	target.Elem().Set(merged)
+	return nil
+}
+
+// mergePatchStruct merges the fields of the patch struct into the target struct. The prefix locates the
+// structs in error messages.
+func mergePatchStruct(target, patch reflect.Value, prefix string) error {
+	for i := 0; i < patch.NumField(); i++ {
+		field := patch.Type().Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := prefix + field.Name
+		dst := target.FieldByName(field.Name)
+		if !dst.IsValid() || !dst.CanSet() {
+			return fmt.Errorf("wrap: cannot merge patch field %s: no such field in %s", name, target.Type())
+		}
+
+		value := patch.Field(i)
+		if optional, ok := value.Interface().(optionalField); ok {
+			set, null, inner := optional.optionalState()
+			if !set {
+				continue
+			}
+			if null {
+				assignReflectValue(dst, reflect.Value{})
+				continue
+			}
+			value = inner
+		} else if value.IsZero() {
+			continue
+		}
+
+		merged, err := mergePatchNested(dst, value, name)
+		if err != nil {
+			return err
+		}
+		if merged {
+			continue
+		}
+		if !assignReflectValue(dst, value) {
+			return fmt.Errorf("wrap: cannot merge patch field %s: %s is not assignable to %s", name, value.Type(), dst.Type())
+		}
+	}
+	return nil
+}
+
+// mergePatchNested merges a nested patch struct into the target field, if it is a struct or a pointer to a struct
+// of a different type, and reports whether it did. Structs behind a pointer are merged into a copy, leaving the
+// original one unchanged for any other reference to it.
+func mergePatchNested(dst, value reflect.Value, name string) (bool, error) {
+	if ptr, ok := value.Interface().(pointerWrapper); ok {
+		value = ptr.reflectPointer()
+	}
+	for value.Kind() == reflect.Pointer && !value.IsNil() {
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return false, nil
+	}
+	structType := dst.Type()
+	indirect := dst.Kind() == reflect.Pointer && structType.Elem().Kind() == reflect.Struct && !value.Type().AssignableTo(structType.Elem())
+	if indirect {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct || value.Type().AssignableTo(structType) {
+		return false, nil
+	}
+	if reflect.PointerTo(structType).Implements(reflect.TypeFor[reflectValueSetter]()) {
+		return false, nil
+	}
+	if !indirect {
+		return true, mergePatchStruct(dst, value, name+".")
+	}
+	copied := reflect.New(structType)
+	if !dst.IsNil() {
+		copied.Elem().Set(dst.Elem())
+	}
+	if err := mergePatchStruct(copied.Elem(), value, name+"."); err != nil {
+		return true, err
+	}
+	dst.Set(copied)
+	return true, nil
+}
+
+// assignReflectValue stores the value into dst, wrapping it into a pointer or a wrapper as needed.
+// An invalid value stands for null and clears dst. It reports whether the value has a compatible type.
+func assignReflectValue(dst, value reflect.Value) bool {
+	if setter, ok := dst.Addr().Interface().(reflectValueSetter); ok {
+		if !value.IsValid() || !value.Type().AssignableTo(dst.Type()) {
+			return setter.setReflectValue(value)
+		}
+	}
+	if !value.IsValid() {
+		dst.SetZero()
+		return true
+	}
+	if ptr, ok := value.Interface().(pointerWrapper); ok && !value.Type().AssignableTo(dst.Type()) {
+		return assignReflectValue(dst, ptr.reflectPointer())
+	}
+	switch {
+	case value.Type().AssignableTo(dst.Type()):
+		dst.Set(value)
+	case dst.Kind() == reflect.Pointer && value.Type().AssignableTo(dst.Type().Elem()):
+		ptr := reflect.New(dst.Type().Elem())
+		ptr.Elem().Set(value)
+		dst.Set(ptr)
+	case value.Kind() == reflect.Pointer:
+		if value.IsNil() {
+			return assignReflectValue(dst, reflect.Value{})
+		}
+		return assignReflectValue(dst, value.Elem())
+	default:
+		return false
+	}
+	return true
+}
+
+// setReflectValue makes the Ptr point to a copy of the provided value, or nil if it is invalid, and reports
+// whether the value has a compatible type.
+func (p *Ptr[T]) setReflectValue(value reflect.Value) bool {
+	if !value.IsValid() {
+		p.X = nil
+		return true
+	}
+	var target T
+	if !assignReflectValue(reflect.ValueOf(&target).Elem(), value) {
+		return false
+	}
+	p.X = &target
+	return true
+}
+
+// reflectPointer returns the underlying pointer as a reflect.Value.
+func (p Ptr[T]) reflectPointer() reflect.Value {
+	return reflect.ValueOf(p.X)
+}
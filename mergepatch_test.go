@@ -0,0 +1,123 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type mergeAddress struct {
+	City string
+	Zip  string
+}
+
+type mergeUser struct {
+	Name     string
+	Nickname *string
+	Email    Ptr[string]
+	Age      int
+	Tags     []string
+	Address  mergeAddress
+	Billing  *mergeAddress
+	Birthday time.Time
+	Status   Optional[string]
+}
+
+type mergeAddressPatch struct {
+	City Optional[string]
+	Zip  Optional[string]
+}
+
+type mergeUserPatch struct {
+	Name     Optional[string]
+	Nickname Optional[string]
+	Email    Optional[string]
+	Age      Optional[int]
+	Tags     []string
+	Address  mergeAddressPatch
+	Billing  Optional[mergeAddressPatch]
+	Birthday Optional[time.Time]
+	Status   Optional[string]
+}
+
+func TestMergePatch(t *testing.T) {
+	nickname, email := "annie", "ann@example.com"
+	user := mergeUser{
+		Name:     "ann",
+		Nickname: &nickname,
+		Email:    NewPtr(&email),
+		Age:      30,
+		Address:  mergeAddress{City: "Rome", Zip: "00100"},
+		Status:   NewOptional("active"),
+	}
+
+	var patch mergeUserPatch
+	err := json.Unmarshal([]byte(`{
+		"Nickname": null,
+		"Email": "new@example.com",
+		"Age": 31,
+		"Tags": ["a"],
+		"Address": {"City": "Milan"},
+		"Billing": {"Zip": "20100"},
+		"Birthday": "2000-01-02T00:00:00Z",
+		"Status": null
+	}`), &patch)
+	assert.NoError(t, err)
+
+	assert.NoError(t, MergePatch(&user, patch))
+	assert.Equal(t, "ann", user.Name, "Absent fields are left unchanged")
+	assert.Nil(t, user.Nickname)
+	assert.Equal(t, "new@example.com", *user.Email.X)
+	assert.NotSame(t, &email, user.Email.X)
+	assert.Equal(t, 31, user.Age)
+	assert.Equal(t, []string{"a"}, user.Tags)
+	assert.Equal(t, mergeAddress{City: "Milan", Zip: "00100"}, user.Address)
+	assert.Equal(t, &mergeAddress{Zip: "20100"}, user.Billing)
+	assert.Equal(t, time.Date(2000, 1, 2, 0, 0, 0, 0, time.UTC), user.Birthday)
+	assert.True(t, user.Status.IsNull())
+	assert.Equal(t, "annie", nickname)
+}
+
+func TestMergePatch_Errors(t *testing.T) {
+	var user mergeUser
+
+	err := MergePatch(user, mergeUserPatch{})
+	assert.Error(t, err, "The target must be a pointer")
+
+	err = MergePatch(&user, 1)
+	assert.Error(t, err, "The patch must be a struct")
+
+	err = MergePatch(&user, struct{ Unknown Optional[int] }{NewOptional(1)})
+	assert.ErrorContains(t, err, "Unknown")
+
+	err = MergePatch(&user, struct{ Age Optional[string] }{NewOptional("old")})
+	assert.ErrorContains(t, err, "Age")
+
+	err = MergePatch(&user, &struct{ Address struct{ Country string } }{struct{ Country string }{"IT"}})
+	assert.ErrorContains(t, err, "Address.Country")
+}
+
+func TestMergePatch_Atomic(t *testing.T) {
+	billing := &mergeAddress{City: "Rome", Zip: "00100"}
+	user := mergeUser{Name: "ann", Age: 30, Billing: billing}
+
+	err := MergePatch(&user, struct {
+		Name    Optional[string]
+		Billing mergeAddressPatch
+		Age     Optional[string]
+	}{
+		Name:    NewOptional("bob"),
+		Billing: mergeAddressPatch{City: NewOptional("Milan")},
+		Age:     NewOptional("old"),
+	})
+	assert.ErrorContains(t, err, "Age")
+	assert.Equal(t, mergeUser{Name: "ann", Age: 30, Billing: billing}, user)
+	assert.Equal(t, mergeAddress{City: "Rome", Zip: "00100"}, *billing)
+
+	err = MergePatch(&user, mergeUserPatch{Billing: NewOptional(mergeAddressPatch{City: NewOptional("Milan")})})
+	assert.NoError(t, err)
+	assert.Equal(t, mergeAddress{City: "Milan", Zip: "00100"}, *user.Billing)
+	assert.Equal(t, mergeAddress{City: "Rome", Zip: "00100"}, *billing, "The shared struct must not be modified")
+}
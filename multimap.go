@@ -0,0 +1,175 @@
+package wrap
+
+import (
+	"encoding/json"
+)
+
+// MultiMap is a generic wrapper for a map associating each key of type K with any number of values of type V.
+// Keys without values are removed, so every key in the map holds at least one value.
+// The zero value is an empty map ready to use.
+type MultiMap[K comparable, V any] struct {
+	X map[K][]V
+}
+
+// NewMultiMap creates a new MultiMap instance with the provided initial map. Keys without values are dropped.
+func NewMultiMap[K comparable, V any](m map[K][]V) MultiMap[K, V] {
+	for key, values := range m {
+		if len(values) == 0 {
+			delete(m, key)
+		}
+	}
+	return MultiMap[K, V]{
+		X: m,
+	}
+}
+
+// MultiMapFromMap creates a new MultiMap instance holding a copy of the values of the provided Map.
+func MultiMapFromMap[K comparable, V any](m Map[K, Slice[V]]) MultiMap[K, V] {
+	mm := MultiMap[K, V]{X: make(map[K][]V, len(m.X))}
+	for key, values := range m.X {
+		mm.Add(key, values.X...)
+	}
+	return mm
+}
+
+// Unwrap returns the underlying map of type map[K][]V.
+func (m *MultiMap[K, V]) Unwrap() map[K][]V {
+	return m.X
+}
+
+// Add appends one or more values to the specified key.
+func (m *MultiMap[K, V]) Add(key K, values ...V) {
+	if len(values) == 0 {
+		return
+	}
+	if m.X == nil {
+		m.X = make(map[K][]V)
+	}
+	m.X[key] = append(m.X[key], values...)
+}
+
+// Get retrieves the first value associated with the specified key and a boolean indicating if the key exists.
+func (m MultiMap[K, V]) Get(key K) (V, bool) {
+	var zero V
+	values, exists := m.X[key]
+	if !exists || len(values) == 0 {
+		return zero, false
+	}
+	return values[0], true
+}
+
+// GetAll returns a new Slice holding all values associated with the specified key, in insertion order.
+func (m MultiMap[K, V]) GetAll(key K) Slice[V] {
+	values := make([]V, len(m.X[key]))
+	copy(values, m.X[key])
+	return NewSlice(values)
+}
+
+// RemoveValue removes the values of the specified key that satisfy the provided comparison function and returns how many were removed.
+// The key is deleted if no values are left.
+func (m *MultiMap[K, V]) RemoveValue(key K, compare func(V) bool) int {
+	values, exists := m.X[key]
+	if !exists {
+		return 0
+	}
+
+	kept := NewSlice(values)
+	removed := kept.Remove(compare)
+	if kept.Length() == 0 {
+		delete(m.X, key)
+	} else {
+		m.X[key] = kept.X
+	}
+	return removed.Length()
+}
+
+// DeleteKey removes the specified key and all its values.
+func (m *MultiMap[K, V]) DeleteKey(key K) {
+	delete(m.X, key)
+}
+
+// Contains checks if the specified key exists in the map.
+func (m MultiMap[K, V]) Contains(key K) bool {
+	_, exists := m.X[key]
+	return exists
+}
+
+// CountValues returns the number of values associated with the specified key.
+func (m MultiMap[K, V]) CountValues(key K) int {
+	return len(m.X[key])
+}
+
+// Len returns the number of keys in the map.
+func (m MultiMap[K, V]) Len() int {
+	return len(m.X)
+}
+
+// Size returns the total number of values in the map, across all keys.
+func (m MultiMap[K, V]) Size() int {
+	size := 0
+	for _, values := range m.X {
+		size += len(values)
+	}
+	return size
+}
+
+// IsEmpty returns true if the map is empty, otherwise false.
+func (m MultiMap[K, V]) IsEmpty() bool {
+	return len(m.X) == 0
+}
+
+// Clear removes all keys and values from the map.
+func (m MultiMap[K, V]) Clear() {
+	for key := range m.X {
+		delete(m.X, key)
+	}
+}
+
+// Clone returns a deep copy of the MultiMap, where the values of each key are copied as well.
+func (m MultiMap[K, V]) Clone() MultiMap[K, V] {
+	return DeepCopy(m)
+}
+
+// Keys returns a slice of all keys in the map.
+func (m MultiMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.X))
+	for key := range m.X {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Entries returns a flattened slice holding one key-value pair per value. Values of the same key are adjacent and in insertion order.
+func (m MultiMap[K, V]) Entries() []MapEntry[K, V] {
+	entries := make([]MapEntry[K, V], 0, m.Size())
+	for key, values := range m.X {
+		for _, value := range values {
+			entries = append(entries, MapEntry[K, V]{Key: key, Value: value})
+		}
+	}
+	return entries
+}
+
+// ToMap returns a new Map holding a copy of the values of each key.
+func (m MultiMap[K, V]) ToMap() Map[K, Slice[V]] {
+	copied := make(map[K]Slice[V], len(m.X))
+	for key := range m.X {
+		copied[key] = m.GetAll(key)
+	}
+	return NewMap(copied)
+}
+
+// UnmarshalJSON unmarshals a JSON object of arrays into the MultiMap. Keys with empty arrays are dropped.
+func (m *MultiMap[K, V]) UnmarshalJSON(data []byte) error {
+	var decoded map[K][]V
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	*m = NewMultiMap(decoded)
+	return nil
+}
+
+// MarshalJSON marshals the MultiMap into a JSON object of arrays.
+func (m MultiMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.X)
+}
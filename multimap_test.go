@@ -0,0 +1,91 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMultiMap_AddAndGet(t *testing.T) {
+	var m MultiMap[string, string]
+	m.Add("accept", "text/html")
+	m.Add("accept", "application/json", "*/*")
+	m.Add("host", "example.com")
+	m.Add("empty")
+
+	assert.Equal(t, 2, m.Len())
+	assert.Equal(t, 4, m.Size())
+	assert.Equal(t, 3, m.CountValues("accept"))
+	assert.False(t, m.Contains("empty"), "Adding no values should not create the key")
+
+	first, ok := m.Get("accept")
+	assert.True(t, ok)
+	assert.Equal(t, "text/html", first)
+
+	all := m.GetAll("accept")
+	assert.Equal(t, []string{"text/html", "application/json", "*/*"}, all.Unwrap())
+
+	all.SetValueAt(0, "changed")
+	first, _ = m.Get("accept")
+	assert.Equal(t, "text/html", first, "GetAll should return a copy")
+
+	missing := m.GetAll("missing")
+	assert.Equal(t, 0, missing.Length())
+	_, ok = m.Get("missing")
+	assert.False(t, ok)
+}
+
+func TestMultiMap_Remove(t *testing.T) {
+	m := NewMultiMap(map[string][]int{"a": {1, 2, 3, 4}, "b": {5}})
+
+	removed := m.RemoveValue("a", func(v int) bool { return v%2 == 0 })
+	assert.Equal(t, 2, removed)
+	all := m.GetAll("a")
+	assert.Equal(t, []int{1, 3}, all.Unwrap())
+
+	removed = m.RemoveValue("b", func(v int) bool { return true })
+	assert.Equal(t, 1, removed)
+	assert.False(t, m.Contains("b"), "Keys without values should be deleted")
+
+	assert.Equal(t, 0, m.RemoveValue("missing", func(int) bool { return true }))
+
+	m.DeleteKey("a")
+	assert.True(t, m.IsEmpty())
+}
+
+func TestMultiMap_KeysAndEntries(t *testing.T) {
+	m := NewMultiMap(map[string][]int{"a": {1, 2}, "b": {3}, "c": {}})
+
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Keys())
+	assert.ElementsMatch(t, []MapEntry[string, int]{{"a", 1}, {"a", 2}, {"b", 3}}, m.Entries())
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestMultiMap_Conversions(t *testing.T) {
+	source := NewMap(map[string]Slice[int]{"a": NewSlice([]int{1, 2}), "b": NewSlice([]int{})})
+	m := MultiMapFromMap(source)
+	assert.Equal(t, map[string][]int{"a": {1, 2}}, m.Unwrap())
+
+	m.Add("a", 3)
+	original, _ := source.Get("a")
+	assert.Equal(t, []int{1, 2}, original.Unwrap(), "MultiMapFromMap should copy the values")
+
+	converted := m.ToMap()
+	a, _ := converted.Get("a")
+	assert.Equal(t, []int{1, 2, 3}, a.Unwrap())
+}
+
+func TestMultiMap_JSON(t *testing.T) {
+	m := NewMultiMap(map[string][]int{"a": {1, 2}, "b": {3}})
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":[1,2],"b":[3]}`, string(data))
+
+	var decoded MultiMap[string, int]
+	err = json.Unmarshal([]byte(`{"x":[1],"y":[]}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]int{"x": {1}}, decoded.Unwrap())
+}
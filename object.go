@@ -0,0 +1,322 @@
+package wrap
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Object is a dynamic JSON document backed by a Map[string, any].
+// Nested objects are stored as Object values and arrays as []any, so they can be walked with dotted paths such as "a.b[2].c".
+type Object Map[string, any]
+
+// NewObject creates a new Object instance with the provided initial map, converting nested maps into Objects.
+func NewObject(m map[string]any) Object {
+	o := Object{X: m}
+	for key, value := range o.X {
+		o.X[key] = normalizeObjectValue(value)
+	}
+	return o
+}
+
+// Unwrap returns the underlying map of type map[string]any.
+func (o Object) Unwrap() map[string]any {
+	return o.X
+}
+
+// ToMap returns the Object as a Map[string, any]. The returned Map shares the underlying map with the Object.
+func (o Object) ToMap() Map[string, any] {
+	return Map[string, any](o)
+}
+
+// Clone returns a deep copy of the Object, where nested objects and arrays are copied as well.
+func (o Object) Clone() Object {
+	return DeepCopy(o)
+}
+
+// Get retrieves the value at the specified dotted path and a boolean indicating if it exists.
+func (o Object) Get(path string) (any, bool) {
+	segments, err := parseObjectPath(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var current any = o
+	for _, segment := range segments {
+		if segment.isIndex {
+			list, ok := asObjectArray(current)
+			if !ok || segment.index >= len(list) {
+				return nil, false
+			}
+			current = list[segment.index]
+			continue
+		}
+
+		object, ok := asObject(current)
+		if !ok {
+			return nil, false
+		}
+		current, ok = object.X[segment.key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+// Set sets the value at the specified dotted path, creating missing parent objects and arrays.
+// It returns an error if the path is invalid or traverses a value that is neither an object nor an array.
+func (o *Object) Set(path string, value any) error {
+	segments, err := parseObjectPath(path)
+	if err != nil {
+		return err
+	}
+	if segments[0].isIndex {
+		return fmt.Errorf("wrap: invalid object path %q: must start with a key", path)
+	}
+
+	if o.X == nil {
+		o.X = make(map[string]any)
+	}
+	_, err = setObjectPath(*o, path, segments, value)
+	return err
+}
+
+// GetString returns the string at the specified path and a boolean indicating if it exists and is a string.
+func (o Object) GetString(path string) (string, bool) {
+	value, ok := o.Get(path)
+	if !ok {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// GetInt returns the integer at the specified path and a boolean indicating if it exists and is an integral number
+// within the range of int.
+func (o Object) GetInt(path string) (int, bool) {
+	value, ok := o.Get(path)
+	if !ok {
+		return 0, false
+	}
+
+	if n, ok := value.(json.Number); ok {
+		i, err := n.Int64()
+		return int(i), err == nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return int(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt {
+			return 0, false
+		}
+		return int(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		// float64(math.MaxInt) rounds up to a power of two, which is out of range itself.
+		f := v.Float()
+		if f != math.Trunc(f) || f < math.MinInt || f >= math.MaxInt {
+			return 0, false
+		}
+		return int(f), true
+	}
+	return 0, false
+}
+
+// GetFloat returns the number at the specified path and a boolean indicating if it exists and is a number.
+func (o Object) GetFloat(path string) (float64, bool) {
+	value, ok := o.Get(path)
+	if !ok {
+		return 0, false
+	}
+
+	if n, ok := value.(json.Number); ok {
+		f, err := n.Float64()
+		return f, err == nil
+	}
+
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int()), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint()), true
+	case reflect.Float32, reflect.Float64:
+		return v.Float(), true
+	}
+	return 0, false
+}
+
+// GetBool returns the boolean at the specified path and a boolean indicating if it exists and is a boolean.
+func (o Object) GetBool(path string) (bool, bool) {
+	value, ok := o.Get(path)
+	if !ok {
+		return false, false
+	}
+	b, ok := value.(bool)
+	return b, ok
+}
+
+// GetObject returns the nested Object at the specified path and a boolean indicating if it exists and is an object.
+func (o Object) GetObject(path string) (Object, bool) {
+	value, ok := o.Get(path)
+	if !ok {
+		return Object{}, false
+	}
+	return asObject(value)
+}
+
+// GetSlice returns the array at the specified path as a Slice and a boolean indicating if it exists and is an array.
+func (o Object) GetSlice(path string) (Slice[any], bool) {
+	value, ok := o.Get(path)
+	if !ok {
+		return Slice[any]{}, false
+	}
+	list, ok := asObjectArray(value)
+	return NewSlice(list), ok
+}
+
+// UnmarshalJSON unmarshals JSON data into the Object. Nested JSON objects become Objects.
+func (o *Object) UnmarshalJSON(data []byte) error {
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return err
+	}
+	*o = NewObject(m)
+	return nil
+}
+
+// MarshalJSON marshals the Object into JSON. It produces a JSON object representation.
+func (o Object) MarshalJSON() ([]byte, error) {
+	return json.Marshal(o.X)
+}
+
+// objectPathSegment is a single step of an Object path: either a key or an array index.
+type objectPathSegment struct {
+	key     string
+	index   int
+	isIndex bool
+}
+
+// parseObjectPath splits a dotted path such as "a.b[2].c" into its segments.
+func parseObjectPath(path string) ([]objectPathSegment, error) {
+	if path == "" {
+		return nil, fmt.Errorf("wrap: invalid object path %q: empty path", path)
+	}
+
+	var segments []objectPathSegment
+	for _, part := range strings.Split(path, ".") {
+		key := part
+		brackets := ""
+		if i := strings.IndexByte(part, '['); i >= 0 {
+			key, brackets = part[:i], part[i:]
+		}
+
+		if key != "" {
+			segments = append(segments, objectPathSegment{key: key})
+		} else if brackets == "" {
+			return nil, fmt.Errorf("wrap: invalid object path %q: empty key", path)
+		}
+
+		for brackets != "" {
+			end := strings.IndexByte(brackets, ']')
+			if brackets[0] != '[' || end < 0 {
+				return nil, fmt.Errorf("wrap: invalid object path %q: malformed index", path)
+			}
+			index, err := strconv.Atoi(brackets[1:end])
+			if err != nil || index < 0 {
+				return nil, fmt.Errorf("wrap: invalid object path %q: invalid index %q", path, brackets[1:end])
+			}
+			segments = append(segments, objectPathSegment{index: index, isIndex: true})
+			brackets = brackets[end+1:]
+		}
+	}
+	return segments, nil
+}
+
+// setObjectPath sets value at segments below current and returns the updated container.
+func setObjectPath(current any, path string, segments []objectPathSegment, value any) (any, error) {
+	if len(segments) == 0 {
+		return normalizeObjectValue(value), nil
+	}
+	segment := segments[0]
+
+	if segment.isIndex {
+		var list []any
+		if current != nil {
+			var ok bool
+			if list, ok = asObjectArray(current); !ok {
+				return nil, fmt.Errorf("wrap: cannot set object path %q: %T is not an array", path, current)
+			}
+		}
+		for len(list) <= segment.index {
+			list = append(list, nil)
+		}
+		child, err := setObjectPath(list[segment.index], path, segments[1:], value)
+		if err != nil {
+			return nil, err
+		}
+		list[segment.index] = child
+		return list, nil
+	}
+
+	object := Object{X: map[string]any{}}
+	if current != nil {
+		var ok bool
+		if object, ok = asObject(current); !ok {
+			return nil, fmt.Errorf("wrap: cannot set object path %q: %T is not an object", path, current)
+		}
+	}
+	child, err := setObjectPath(object.X[segment.key], path, segments[1:], value)
+	if err != nil {
+		return nil, err
+	}
+	object.X[segment.key] = child
+	return object, nil
+}
+
+// asObject returns value as an Object if it holds an object representation.
+func asObject(value any) (Object, bool) {
+	switch v := value.(type) {
+	case Object:
+		return v, true
+	case Map[string, any]:
+		return Object(v), true
+	case map[string]any:
+		return Object{X: v}, true
+	}
+	return Object{}, false
+}
+
+// asObjectArray returns value as a []any if it holds an array representation.
+func asObjectArray(value any) ([]any, bool) {
+	switch v := value.(type) {
+	case []any:
+		return v, true
+	case Slice[any]:
+		return v.X, true
+	}
+	return nil, false
+}
+
+// normalizeObjectValue converts nested maps into Objects and nested arrays into []any.
+func normalizeObjectValue(value any) any {
+	if object, ok := asObject(value); ok {
+		if object.X == nil {
+			object.X = make(map[string]any)
+		}
+		return NewObject(object.X)
+	}
+	if list, ok := asObjectArray(value); ok {
+		for i, item := range list {
+			list[i] = normalizeObjectValue(item)
+		}
+		return list
+	}
+	return value
+}
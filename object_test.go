@@ -0,0 +1,151 @@
+package wrap
+
+import (
+	"encoding/json"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObject_Get(t *testing.T) {
+	var o Object
+	err := json.Unmarshal([]byte(`{"a":{"b":[1,{"c":"x"},true]},"n":1.5,"name":"test"}`), &o)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		path     string
+		expected any
+		exists   bool
+	}{
+		{"name", "test", true},
+		{"n", 1.5, true},
+		{"a.b[0]", float64(1), true},
+		{"a.b[1].c", "x", true},
+		{"a.b[2]", true, true},
+		{"a.b[3]", nil, false}, // out of bounds
+		{"a.x", nil, false},    // missing key
+		{"name.x", nil, false}, // not an object
+		{"a[0]", nil, false},   // not an array
+		{"a..b", nil, false},   // invalid path
+		{"a.b[x]", nil, false}, // invalid index
+	}
+
+	for _, tt := range tests {
+		value, exists := o.Get(tt.path)
+		assert.Equal(t, tt.exists, exists, "Path '%s' existence should be %v", tt.path, tt.exists)
+		assert.Equal(t, tt.expected, value, "Value at path '%s' should be %v", tt.path, tt.expected)
+	}
+}
+
+func TestObject_TypedGetters(t *testing.T) {
+	o := NewObject(map[string]any{
+		"s":      "text",
+		"i":      float64(3),
+		"f":      2.5,
+		"b":      true,
+		"nested": map[string]any{"k": "v"},
+		"list":   []any{1, 2},
+		"num":    json.Number("7"),
+		"huge":   1e300,
+		"max":    float64(math.MaxInt),
+		"min":    float64(math.MinInt),
+		"uint":   uint64(math.MaxUint64),
+	})
+
+	s, ok := o.GetString("s")
+	assert.True(t, ok)
+	assert.Equal(t, "text", s)
+
+	i, ok := o.GetInt("i")
+	assert.True(t, ok)
+	assert.Equal(t, 3, i)
+
+	_, ok = o.GetInt("f")
+	assert.False(t, ok, "Non-integral numbers are not ints")
+
+	i, ok = o.GetInt("num")
+	assert.True(t, ok)
+	assert.Equal(t, 7, i)
+
+	_, ok = o.GetInt("huge")
+	assert.False(t, ok, "Floats out of the int range are not ints")
+	_, ok = o.GetInt("max")
+	assert.False(t, ok, "float64(math.MaxInt) is out of the int range")
+	i, ok = o.GetInt("min")
+	assert.True(t, ok)
+	assert.Equal(t, math.MinInt, i)
+	_, ok = o.GetInt("uint")
+	assert.False(t, ok, "Unsigned integers above math.MaxInt are not ints")
+
+	f, ok := o.GetFloat("f")
+	assert.True(t, ok)
+	assert.Equal(t, 2.5, f)
+
+	b, ok := o.GetBool("b")
+	assert.True(t, ok)
+	assert.True(t, b)
+
+	nested, ok := o.GetObject("nested")
+	assert.True(t, ok)
+	value, _ := nested.GetString("k")
+	assert.Equal(t, "v", value)
+
+	list, ok := o.GetSlice("list")
+	assert.True(t, ok)
+	assert.Equal(t, []any{1, 2}, list.Unwrap())
+
+	_, ok = o.GetString("b")
+	assert.False(t, ok, "Type mismatch should return false")
+	_, ok = o.GetObject("s")
+	assert.False(t, ok, "Type mismatch should return false")
+}
+
+func TestObject_Set(t *testing.T) {
+	var o Object
+	assert.NoError(t, o.Set("a.b[2].c", "x"))
+	assert.NoError(t, o.Set("a.d", map[string]any{"e": 1}))
+	assert.NoError(t, o.Set("top", 1))
+
+	data, err := json.Marshal(o)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":{"b":[null,null,{"c":"x"}],"d":{"e":1}},"top":1}`, string(data))
+
+	d, ok := o.Get("a.d")
+	assert.True(t, ok)
+	assert.IsType(t, Object{}, d, "Nested maps should be stored as Objects")
+
+	assert.NoError(t, o.Set("a.b[0]", "y"))
+	value, _ := o.GetString("a.b[0]")
+	assert.Equal(t, "y", value)
+
+	assert.Error(t, o.Set("top.x", 1), "Setting below a scalar should fail")
+	assert.Error(t, o.Set("a[0]", 1), "Indexing an object should fail")
+	assert.Error(t, o.Set("[0]", 1), "Paths must start with a key")
+	assert.Error(t, o.Set("", 1), "Empty paths should fail")
+}
+
+func TestObject_JSON(t *testing.T) {
+	var o Object
+	err := json.Unmarshal([]byte(`{"a":{"b":[{"c":1}]}}`), &o)
+	assert.NoError(t, err)
+
+	a, _ := o.Get("a")
+	assert.IsType(t, Object{}, a)
+	c, _ := o.Get("a.b[0]")
+	assert.IsType(t, Object{}, c)
+
+	data, err := json.Marshal(o)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":{"b":[{"c":1}]}}`, string(data))
+}
+
+func TestObject_ToMap(t *testing.T) {
+	o := NewObject(map[string]any{"a": 1})
+	m := o.ToMap()
+	m.Set("b", 2)
+
+	assert.True(t, m.Contains("a"))
+	_, ok := o.Get("b")
+	assert.True(t, ok, "ToMap should share the underlying map")
+}
@@ -0,0 +1,94 @@
+package wrap
+
+import (
+	"slices"
+	"sync"
+)
+
+// EventKind identifies the kind of change described by an event of an observable wrapper.
+type EventKind string
+
+const (
+	// EventSet reports a value set, either replacing an old value or, for maps and pointers, adding a new one.
+	EventSet EventKind = "set"
+	// EventDelete reports a key removed from a map.
+	EventDelete EventKind = "delete"
+	// EventInsert reports values inserted into a slice at an index.
+	EventInsert EventKind = "insert"
+	// EventRemove reports values removed from a slice at an index.
+	EventRemove EventKind = "remove"
+	// EventClear reports that all values were removed.
+	EventClear EventKind = "clear"
+)
+
+// broadcaster delivers events to a set of subscribers. Subscribing and unsubscribing are safe for concurrent use.
+// Events are delivered synchronously, in the goroutine that publishes them, without holding any lock.
+type broadcaster[E any] struct {
+	mu          sync.Mutex
+	subscribers []*subscriber[E]
+}
+
+// subscriber is a callback registered with a broadcaster.
+type subscriber[E any] struct {
+	notify func(E)
+}
+
+// subscribe registers a callback and returns a function that unregisters it.
+func (b *broadcaster[E]) subscribe(notify func(E)) func() {
+	s := &subscriber[E]{notify: notify}
+
+	b.mu.Lock()
+	b.subscribers = append(b.subscribers, s)
+	b.mu.Unlock()
+
+	return sync.OnceFunc(func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		i := slices.Index(b.subscribers, s)
+		// Copy on removal, publish may be iterating over the current slice.
+		b.subscribers = append(b.subscribers[:i:i], b.subscribers[i+1:]...)
+	})
+}
+
+// subscribeChan registers a channel receiving the events and returns a function that unregisters and closes it.
+// Publishing blocks while the channel buffer is full, until the event is received or the channel is unsubscribed.
+func (b *broadcaster[E]) subscribeChan(buffer int) (<-chan E, func()) {
+	events := make(chan E, buffer)
+	done := make(chan struct{})
+	var sending sync.RWMutex
+
+	unsubscribe := b.subscribe(func(event E) {
+		sending.RLock()
+		defer sending.RUnlock()
+		select {
+		case <-done:
+			return
+		default:
+		}
+		select {
+		case events <- event:
+		case <-done:
+		}
+	})
+
+	return events, sync.OnceFunc(func() {
+		unsubscribe()
+		close(done)
+		sending.Lock()
+		defer sending.Unlock()
+		close(events)
+	})
+}
+
+// publish delivers the events, in order, to every current subscriber.
+func (b *broadcaster[E]) publish(events ...E) {
+	b.mu.Lock()
+	subscribers := b.subscribers
+	b.mu.Unlock()
+
+	for _, event := range events {
+		for _, s := range subscribers {
+			s.notify(event)
+		}
+	}
+}
@@ -0,0 +1,68 @@
+package wrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBroadcaster_Subscribe(t *testing.T) {
+	var b broadcaster[int]
+	var first, second []int
+	unsubscribe := b.subscribe(func(event int) { first = append(first, event) })
+	b.subscribe(func(event int) { second = append(second, event) })
+
+	b.publish(1, 2)
+	unsubscribe()
+	unsubscribe() // must be a no-op
+	b.publish(3)
+
+	assert.Equal(t, []int{1, 2}, first)
+	assert.Equal(t, []int{1, 2, 3}, second)
+}
+
+func TestBroadcaster_UnsubscribeDuringPublish(t *testing.T) {
+	var b broadcaster[int]
+	var received []int
+	var unsubscribe func()
+	unsubscribe = b.subscribe(func(event int) {
+		received = append(received, event)
+		unsubscribe()
+	})
+	b.subscribe(func(event int) { received = append(received, -event) })
+
+	b.publish(1)
+	b.publish(2)
+	assert.Equal(t, []int{1, -1, -2}, received)
+}
+
+func TestBroadcaster_SubscribeChan(t *testing.T) {
+	var b broadcaster[int]
+	events, unsubscribe := b.subscribeChan(2)
+
+	b.publish(1, 2)
+	assert.Equal(t, 1, <-events)
+	assert.Equal(t, 2, <-events)
+
+	unsubscribe()
+	_, ok := <-events
+	assert.False(t, ok, "The channel should be closed")
+	b.publish(3) // must not panic
+}
+
+func TestBroadcaster_UnsubscribeUnblocksPublish(t *testing.T) {
+	var b broadcaster[int]
+	publishing := make(chan struct{})
+	b.subscribe(func(int) { close(publishing) })
+	_, unsubscribe := b.subscribeChan(0)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.publish(1) // blocks: nobody receives
+	}()
+
+	<-publishing
+	unsubscribe()
+	<-done
+}
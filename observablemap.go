@@ -0,0 +1,125 @@
+package wrap
+
+import (
+	"encoding/json"
+	"reflect"
+)
+
+// MapEvent describes a change of an ObservableMap. EventSet reports the Old value, if any, and the New value
+// of Key, EventDelete reports the Old value of Key, and EventClear has no key.
+type MapEvent[K comparable, V any] struct {
+	Kind EventKind `json:"kind"`
+	Key  K         `json:"key"`
+	Old  Ptr[V]    `json:"old,omitzero"`
+	New  Ptr[V]    `json:"new,omitzero"`
+}
+
+// ObservableMap is a Map that publishes a MapEvent to its subscribers on every change.
+// Like Map, it is not safe for concurrent modification, but subscribing and unsubscribing are.
+// The zero value is an empty map ready to use.
+type ObservableMap[K comparable, V any] struct {
+	m      Map[K, V]
+	events broadcaster[MapEvent[K, V]]
+}
+
+// NewObservableMap creates a new ObservableMap instance with the provided initial map.
+func NewObservableMap[K comparable, V any](m map[K]V) *ObservableMap[K, V] {
+	return &ObservableMap[K, V]{m: NewMap(m)}
+}
+
+// Unwrap returns the underlying map of type map[K]V. The map must not be modified through it.
+func (m *ObservableMap[K, V]) Unwrap() map[K]V {
+	return m.m.X
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (m *ObservableMap[K, V]) Get(key K) (V, bool) {
+	return m.m.Get(key)
+}
+
+// Contains checks if the specified key exists in the map.
+func (m *ObservableMap[K, V]) Contains(key K) bool {
+	return m.m.Contains(key)
+}
+
+// Keys returns a slice of all keys in the map.
+func (m *ObservableMap[K, V]) Keys() []K {
+	return m.m.Keys()
+}
+
+// Values returns a slice of all values in the map.
+func (m *ObservableMap[K, V]) Values() Slice[V] {
+	return m.m.Values()
+}
+
+// Len returns the number of key-value pairs in the map.
+func (m *ObservableMap[K, V]) Len() int {
+	return m.m.Len()
+}
+
+// IsEmpty returns true if the map is empty, otherwise false.
+func (m *ObservableMap[K, V]) IsEmpty() bool {
+	return m.m.IsEmpty()
+}
+
+// Iter returns a lazy sequence over the key-value pairs of the map, in no particular order.
+func (m *ObservableMap[K, V]) Iter() Seq2[K, V] {
+	return m.m.Iter()
+}
+
+// Set adds or updates the value for the specified key, publishes an EventSet and returns the ObservableMap instance.
+func (m *ObservableMap[K, V]) Set(key K, value V) *ObservableMap[K, V] {
+	if m.m.X == nil {
+		m.m.X = make(map[K]V)
+	}
+	old := NewNilPtr[V]()
+	if previous, ok := m.m.Get(key); ok {
+		old = NewPtr(&previous)
+	}
+	m.m.Set(key, value)
+	m.events.publish(MapEvent[K, V]{Kind: EventSet, Key: key, Old: old, New: NewPtr(&value)})
+	return m
+}
+
+// Delete removes the key-value pair associated with the specified key, publishes an EventDelete if it existed
+// and returns the ObservableMap instance.
+func (m *ObservableMap[K, V]) Delete(key K) *ObservableMap[K, V] {
+	old, ok := m.m.Get(key)
+	if !ok {
+		return m
+	}
+	m.m.Delete(key)
+	m.events.publish(MapEvent[K, V]{Kind: EventDelete, Key: key, Old: NewPtr(&old)})
+	return m
+}
+
+// Clear removes all key-value pairs from the map and publishes an EventClear if it was not empty.
+func (m *ObservableMap[K, V]) Clear() {
+	if m.m.IsEmpty() {
+		return
+	}
+	m.m.Clear()
+	m.events.publish(MapEvent[K, V]{Kind: EventClear})
+}
+
+// Subscribe registers a function called with every change and returns a function that unregisters it.
+// The function runs in the goroutine making the change, after the change is applied.
+func (m *ObservableMap[K, V]) Subscribe(fn func(MapEvent[K, V])) (unsubscribe func()) {
+	return m.events.subscribe(fn)
+}
+
+// SubscribeChan returns a channel receiving every change and a function that unregisters and closes it.
+// Changes block while the channel buffer is full, until the event is received or the channel is unsubscribed.
+func (m *ObservableMap[K, V]) SubscribeChan(buffer int) (<-chan MapEvent[K, V], func()) {
+	return m.events.subscribeChan(buffer)
+}
+
+// MarshalJSON marshals the ObservableMap into JSON.
+func (m *ObservableMap[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.m.X)
+}
+
+// cloneReflect returns a deep copy of the ObservableMap for DeepCopy. Subscribers are not copied.
+func (m *ObservableMap[K, V]) cloneReflect(c *cloneState) reflect.Value {
+	return reflect.ValueOf(&ObservableMap[K, V]{m: cloneValue(c, m.m)}).Elem()
+}
@@ -0,0 +1,56 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableMap_Events(t *testing.T) {
+	var m ObservableMap[string, int]
+	var events []MapEvent[string, int]
+	m.Subscribe(func(event MapEvent[string, int]) { events = append(events, event) })
+
+	m.Set("a", 1).Set("a", 2).Set("b", 3)
+	m.Delete("a").Delete("missing")
+	m.Clear()
+	m.Clear() // empty, no event
+
+	assert.Equal(t, []MapEvent[string, int]{
+		{Kind: EventSet, Key: "a", New: NewPtr(intPtr(1))},
+		{Kind: EventSet, Key: "a", Old: NewPtr(intPtr(1)), New: NewPtr(intPtr(2))},
+		{Kind: EventSet, Key: "b", New: NewPtr(intPtr(3))},
+		{Kind: EventDelete, Key: "a", Old: NewPtr(intPtr(2))},
+		{Kind: EventClear},
+	}, events)
+	assert.True(t, m.IsEmpty())
+}
+
+func TestObservableMap_CallbackCanReadMap(t *testing.T) {
+	m := NewObservableMap(map[string]int{})
+	var lengths []int
+	m.Subscribe(func(event MapEvent[string, int]) { lengths = append(lengths, m.Len()) })
+
+	m.Set("a", 1).Set("b", 2).Delete("a")
+	assert.Equal(t, []int{1, 2, 1}, lengths)
+}
+
+func TestObservableMap_JSON(t *testing.T) {
+	m := NewObservableMap(map[string]int{"a": 1})
+	events, unsubscribe := m.SubscribeChan(2)
+	defer unsubscribe()
+
+	m.Set("a", 2).Delete("a")
+	data, err := json.Marshal(<-events)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"set","key":"a","old":1,"new":2}`, string(data))
+	data, err = json.Marshal(<-events)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"delete","key":"a","old":2}`, string(data))
+
+	m.Set("b", 3)
+	data, err = json.Marshal(m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"b":3}`, string(data))
+}
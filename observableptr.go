@@ -0,0 +1,86 @@
+package wrap
+
+import "reflect"
+
+// PtrEvent describes a change of an ObservablePtr. Old and New are nil when there was, or is, no value.
+type PtrEvent[T any] struct {
+	Kind EventKind `json:"kind"`
+	Old  Ptr[T]    `json:"old,omitzero"`
+	New  Ptr[T]    `json:"new,omitzero"`
+}
+
+// ObservablePtr is a Ptr that publishes a PtrEvent to its subscribers on every change.
+// Like Ptr, it is not safe for concurrent modification, but subscribing and unsubscribing are.
+// The zero value is a nil pointer ready to use.
+type ObservablePtr[T any] struct {
+	p      Ptr[T]
+	events broadcaster[PtrEvent[T]]
+}
+
+// NewObservablePtr creates a new ObservablePtr instance with the provided pointer to T.
+func NewObservablePtr[T any](ptr *T) *ObservablePtr[T] {
+	return &ObservablePtr[T]{p: NewPtr(ptr)}
+}
+
+// Unwrap returns the underlying pointer of type T. The value must not be modified through it.
+func (p *ObservablePtr[T]) Unwrap() *T {
+	return p.p.X
+}
+
+// GetValue returns the value pointed to by the ObservablePtr and a boolean indicating if the pointer is non-nil.
+func (p *ObservablePtr[T]) GetValue() (T, bool) {
+	return p.p.GetValue()
+}
+
+// SetValue sets the value of the pointer and publishes an EventSet.
+func (p *ObservablePtr[T]) SetValue(value T) {
+	old := p.snapshot()
+	p.p.SetValue(value)
+	p.events.publish(PtrEvent[T]{Kind: EventSet, Old: old, New: NewPtr(&value)})
+}
+
+// Clear sets the pointer to nil and publishes an EventClear if it held a value.
+func (p *ObservablePtr[T]) Clear() {
+	if p.p.IsNil() {
+		return
+	}
+	old := p.snapshot()
+	p.p.Clear()
+	p.events.publish(PtrEvent[T]{Kind: EventClear, Old: old})
+}
+
+// IsNil returns true if the pointer is nil, otherwise false.
+func (p *ObservablePtr[T]) IsNil() bool {
+	return p.p.IsNil()
+}
+
+// Subscribe registers a function called with every change and returns a function that unregisters it.
+// The function runs in the goroutine making the change, after the change is applied.
+func (p *ObservablePtr[T]) Subscribe(fn func(PtrEvent[T])) (unsubscribe func()) {
+	return p.events.subscribe(fn)
+}
+
+// SubscribeChan returns a channel receiving every change and a function that unregisters and closes it.
+// Changes block while the channel buffer is full, until the event is received or the channel is unsubscribed.
+func (p *ObservablePtr[T]) SubscribeChan(buffer int) (<-chan PtrEvent[T], func()) {
+	return p.events.subscribeChan(buffer)
+}
+
+// MarshalJSON marshals the ObservablePtr into JSON. If the pointer is nil, it serializes as "null".
+func (p *ObservablePtr[T]) MarshalJSON() ([]byte, error) {
+	return p.p.MarshalJSON()
+}
+
+// snapshot returns a Ptr to a copy of the current value, since SetValue modifies the value in place.
+func (p *ObservablePtr[T]) snapshot() Ptr[T] {
+	value, ok := p.p.GetValue()
+	if !ok {
+		return NewNilPtr[T]()
+	}
+	return NewPtr(&value)
+}
+
+// cloneReflect returns a deep copy of the ObservablePtr for DeepCopy. Subscribers are not copied.
+func (p *ObservablePtr[T]) cloneReflect(c *cloneState) reflect.Value {
+	return reflect.ValueOf(&ObservablePtr[T]{p: cloneValue(c, p.p)}).Elem()
+}
@@ -0,0 +1,52 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservablePtr_Events(t *testing.T) {
+	var p ObservablePtr[int]
+	var events []PtrEvent[int]
+	p.Subscribe(func(event PtrEvent[int]) { events = append(events, event) })
+
+	p.SetValue(1)
+	p.SetValue(2)
+	p.Clear()
+	p.Clear() // no value, no event
+
+	assert.Len(t, events, 3)
+	assert.Equal(t, EventSet, events[0].Kind)
+	assert.True(t, events[0].Old.IsNil())
+	assert.Equal(t, 1, *events[0].New.X)
+
+	assert.Equal(t, 1, *events[1].Old.X, "The old value must not be overwritten in place")
+	assert.Equal(t, 2, *events[1].New.X)
+
+	assert.Equal(t, EventClear, events[2].Kind)
+	assert.Equal(t, 2, *events[2].Old.X)
+	assert.True(t, p.IsNil())
+}
+
+func TestObservablePtr_JSON(t *testing.T) {
+	value := 1
+	p := NewObservablePtr(&value)
+	events, unsubscribe := p.SubscribeChan(1)
+	defer unsubscribe()
+
+	p.SetValue(2)
+	data, err := json.Marshal(<-events)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"set","old":1,"new":2}`, string(data))
+
+	p.Clear()
+	data, err = json.Marshal(<-events)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"kind":"clear","old":2}`, string(data))
+
+	data, err = json.Marshal(p)
+	assert.NoError(t, err)
+	assert.Equal(t, `null`, string(data))
+}
@@ -0,0 +1,170 @@
+package wrap
+
+import (
+	"encoding/json"
+	"reflect"
+	"slices"
+)
+
+// SliceEvent describes a change of an ObservableSlice. EventSet reports the Old and New value at Index,
+// EventInsert and EventRemove report the Values inserted or removed at Index, and EventClear reports
+// the Values that were removed.
+type SliceEvent[T any] struct {
+	Kind   EventKind `json:"kind"`
+	Index  int       `json:"index"`
+	Old    Ptr[T]    `json:"old,omitzero"`
+	New    Ptr[T]    `json:"new,omitzero"`
+	Values []T       `json:"values,omitempty"`
+}
+
+// ObservableSlice is a Slice that publishes a SliceEvent to its subscribers on every change.
+// Like Slice, it is not safe for concurrent modification, but subscribing and unsubscribing are.
+// The zero value is an empty slice ready to use.
+type ObservableSlice[T any] struct {
+	s      Slice[T]
+	events broadcaster[SliceEvent[T]]
+}
+
+// NewObservableSlice creates a new ObservableSlice instance with the provided initial slice.
+func NewObservableSlice[T any](slice []T) *ObservableSlice[T] {
+	return &ObservableSlice[T]{s: NewSlice(slice)}
+}
+
+// Unwrap returns the underlying slice of type T. The slice must not be modified through it.
+func (s *ObservableSlice[T]) Unwrap() []T {
+	return s.s.X
+}
+
+// ValueAt retrieves the value at the specified index and a boolean indicating success.
+func (s *ObservableSlice[T]) ValueAt(index int) (T, bool) {
+	return s.s.ValueAt(index)
+}
+
+// Length returns the number of elements in the slice.
+func (s *ObservableSlice[T]) Length() int {
+	return s.s.Length()
+}
+
+// Copy returns a new Slice that is a copy of the current slice.
+func (s *ObservableSlice[T]) Copy() Slice[T] {
+	return s.s.Copy()
+}
+
+// IndexOf returns the index of the first element that satisfies the provided equality function, or -1 if not found.
+func (s *ObservableSlice[T]) IndexOf(equals func(T) bool) (int, bool) {
+	return s.s.IndexOf(equals)
+}
+
+// Find returns the first element that satisfies the provided predicate function, or a zero value if not found.
+func (s *ObservableSlice[T]) Find(predicate func(T) bool) (T, bool) {
+	return s.s.Find(predicate)
+}
+
+// Iter returns a lazy sequence over the values of the slice.
+func (s *ObservableSlice[T]) Iter() Seq[T] {
+	return s.s.Iter()
+}
+
+// SetValueAt sets the value at the specified index, publishes an EventSet and returns whether the operation was successful.
+func (s *ObservableSlice[T]) SetValueAt(index int, value T) bool {
+	old, ok := s.s.ValueAt(index)
+	if !ok {
+		return false
+	}
+	s.s.SetValueAt(index, value)
+	s.events.publish(SliceEvent[T]{Kind: EventSet, Index: index, Old: NewPtr(&old), New: NewPtr(&value)})
+	return true
+}
+
+// Append adds one or more values to the end of the slice and publishes an EventInsert.
+func (s *ObservableSlice[T]) Append(values ...T) {
+	s.InsertAt(len(s.s.X), values...)
+}
+
+// Prepend adds one or more values to the beginning of the slice and publishes an EventInsert.
+func (s *ObservableSlice[T]) Prepend(values ...T) {
+	s.InsertAt(0, values...)
+}
+
+// InsertAt inserts one or more values at the specified index, publishes an EventInsert and returns whether the operation was successful.
+func (s *ObservableSlice[T]) InsertAt(index int, values ...T) bool {
+	if !s.s.InsertAt(index, values...) {
+		return false
+	}
+	if len(values) > 0 {
+		s.events.publish(SliceEvent[T]{Kind: EventInsert, Index: index, Values: slices.Clone(values)})
+	}
+	return true
+}
+
+// Pop removes and returns the last value from the slice, or false if the slice is empty, and publishes an EventRemove.
+func (s *ObservableSlice[T]) Pop() (T, bool) {
+	removed := s.RemoveAt(len(s.s.X) - 1)
+	return removed.ValueAt(0)
+}
+
+// Shift removes and returns the first value from the slice, or false if the slice is empty, and publishes an EventRemove.
+func (s *ObservableSlice[T]) Shift() (T, bool) {
+	removed := s.RemoveAt(0)
+	return removed.ValueAt(0)
+}
+
+// RemoveAt removes a specified number of elements starting from the index, publishes an EventRemove and returns the removed elements as a new Slice.
+func (s *ObservableSlice[T]) RemoveAt(index int, count ...int) Slice[T] {
+	removed := s.s.RemoveAt(index, count...)
+	if len(removed.X) > 0 {
+		s.events.publish(SliceEvent[T]{Kind: EventRemove, Index: index, Values: slices.Clone(removed.X)})
+	}
+	return removed
+}
+
+// Remove deletes all elements that satisfy the provided comparison function and returns removed elements as a new Slice.
+// It publishes an EventRemove per element, with indexes relative to the slice after the previous events.
+func (s *ObservableSlice[T]) Remove(compare func(T) bool) Slice[T] {
+	var events []SliceEvent[T]
+	removed := NewSlice([]T{})
+	kept := s.s.X[:0]
+	for _, value := range s.s.X {
+		if !compare(value) {
+			kept = append(kept, value)
+			continue
+		}
+		events = append(events, SliceEvent[T]{Kind: EventRemove, Index: len(kept), Values: []T{value}})
+		removed.Append(value)
+	}
+	clear(s.s.X[len(kept):])
+	s.s.X = kept
+	s.events.publish(events...)
+	return removed
+}
+
+// Clear removes all elements from the slice and publishes an EventClear if it was not empty.
+func (s *ObservableSlice[T]) Clear() {
+	old := s.s.X
+	s.s.Clear()
+	if len(old) > 0 {
+		s.events.publish(SliceEvent[T]{Kind: EventClear, Values: old})
+	}
+}
+
+// Subscribe registers a function called with every change and returns a function that unregisters it.
+// The function runs in the goroutine making the change, after the change is applied.
+func (s *ObservableSlice[T]) Subscribe(fn func(SliceEvent[T])) (unsubscribe func()) {
+	return s.events.subscribe(fn)
+}
+
+// SubscribeChan returns a channel receiving every change and a function that unregisters and closes it.
+// Changes block while the channel buffer is full, until the event is received or the channel is unsubscribed.
+func (s *ObservableSlice[T]) SubscribeChan(buffer int) (<-chan SliceEvent[T], func()) {
+	return s.events.subscribeChan(buffer)
+}
+
+// MarshalJSON marshals the ObservableSlice into JSON.
+func (s *ObservableSlice[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.s.X)
+}
+
+// cloneReflect returns a deep copy of the ObservableSlice for DeepCopy. Subscribers are not copied.
+func (s *ObservableSlice[T]) cloneReflect(c *cloneState) reflect.Value {
+	return reflect.ValueOf(&ObservableSlice[T]{s: cloneValue(c, s.s)}).Elem()
+}
@@ -0,0 +1,119 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableSlice_Events(t *testing.T) {
+	s := NewObservableSlice([]int{1, 2, 3})
+	var events []SliceEvent[int]
+	unsubscribe := s.Subscribe(func(event SliceEvent[int]) { events = append(events, event) })
+
+	s.Append(4, 5)
+	s.Prepend(0)
+	s.InsertAt(2, 10)
+	s.SetValueAt(0, -1)
+	s.RemoveAt(1, 2)
+	s.Pop()
+	s.Shift()
+	s.Append()           // no values, no event
+	s.InsertAt(100, 1)   // out of bounds, no event
+	s.RemoveAt(100)      // out of bounds, no event
+	s.SetValueAt(100, 1) // out of bounds, no event
+
+	assert.Equal(t, []SliceEvent[int]{
+		{Kind: EventInsert, Index: 3, Values: []int{4, 5}},
+		{Kind: EventInsert, Index: 0, Values: []int{0}},
+		{Kind: EventInsert, Index: 2, Values: []int{10}},
+		{Kind: EventSet, Index: 0, Old: NewPtr(intPtr(0)), New: NewPtr(intPtr(-1))},
+		{Kind: EventRemove, Index: 1, Values: []int{1, 10}},
+		{Kind: EventRemove, Index: 4, Values: []int{5}},
+		{Kind: EventRemove, Index: 0, Values: []int{-1}},
+	}, events)
+	assert.Equal(t, []int{2, 3, 4}, s.Unwrap())
+
+	unsubscribe()
+	s.Clear()
+	assert.Len(t, events, 7)
+}
+
+func intPtr(value int) *int {
+	return &value
+}
+
+func TestObservableSlice_RemoveReplaysInOrder(t *testing.T) {
+	s := NewObservableSlice([]int{1, 2, 3, 4, 5, 6})
+	mirror := NewSlice([]int{1, 2, 3, 4, 5, 6})
+	s.Subscribe(func(event SliceEvent[int]) {
+		assert.Equal(t, EventRemove, event.Kind)
+		mirror.RemoveAt(event.Index, len(event.Values))
+	})
+
+	removed := s.Remove(func(v int) bool { return v%2 == 0 })
+	assert.Equal(t, []int{2, 4, 6}, removed.X)
+	assert.Equal(t, s.Unwrap(), mirror.X)
+}
+
+func TestObservableSlice_RemoveCallsPredicateOnce(t *testing.T) {
+	s := NewObservableSlice([]int{1, 1, 1, 1})
+	var events []SliceEvent[int]
+	s.Subscribe(func(event SliceEvent[int]) { events = append(events, event) })
+
+	calls := 0
+	removed := s.Remove(func(int) bool {
+		calls++
+		return calls%2 == 0 // stateful: removes every other element
+	})
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, []int{1, 1}, removed.X)
+	assert.Equal(t, []int{1, 1}, s.Unwrap())
+	assert.Equal(t, []SliceEvent[int]{
+		{Kind: EventRemove, Index: 1, Values: []int{1}},
+		{Kind: EventRemove, Index: 2, Values: []int{1}},
+	}, events)
+}
+
+func TestObservableSlice_Clear(t *testing.T) {
+	var s ObservableSlice[string]
+	events, unsubscribe := s.SubscribeChan(10)
+	s.Clear() // empty, no event
+	s.Append("a", "b")
+	s.Clear()
+	unsubscribe()
+
+	var received []SliceEvent[string]
+	for event := range events {
+		received = append(received, event)
+	}
+	assert.Len(t, received, 2)
+	assert.Equal(t, EventClear, received[1].Kind)
+	assert.Equal(t, []string{"a", "b"}, received[1].Values)
+}
+
+func TestObservableSlice_JSON(t *testing.T) {
+	s := NewObservableSlice([]string{"a"})
+	var data []byte
+	s.Subscribe(func(event SliceEvent[string]) {
+		var err error
+		data, err = json.Marshal(event)
+		assert.NoError(t, err)
+	})
+
+	s.SetValueAt(0, "b")
+	assert.Equal(t, `{"kind":"set","index":0,"old":"a","new":"b"}`, string(data))
+
+	s.Append("c")
+	assert.Equal(t, `{"kind":"insert","index":1,"values":["c"]}`, string(data))
+
+	var decoded SliceEvent[string]
+	err := json.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, SliceEvent[string]{Kind: EventInsert, Index: 1, Values: []string{"c"}}, decoded)
+
+	data, err = json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `["b","c"]`, string(data))
+}
@@ -0,0 +1,199 @@
+package wrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Optional is a tri-state wrapper for a value of type T, telling apart an absent value, an explicit null and
+// a value. It is meant for the fields of partial updates, such as PATCH request bodies, where a missing field
+// leaves the target unchanged while a null one clears it. The zero value is absent.
+//
+// JSON and XML tell apart all three states. YAML only supports absent and set values: yaml.v3 never calls
+// unmarshalers for null nodes, so a null YAML field leaves the Optional unchanged, absent when freshly decoded.
+// Use JSON or XML when clearing fields must be supported.
+//
+// Tag fields with `json:",omitzero"`, `yaml:",omitempty"` to omit absent values on marshal; XML never writes them.
+type Optional[T any] struct {
+	value T
+	set   bool
+	null  bool
+}
+
+// NewOptional creates a new Optional instance holding the provided value.
+func NewOptional[T any](value T) Optional[T] {
+	return Optional[T]{value: value, set: true}
+}
+
+// NewNullOptional creates a new Optional instance holding an explicit null.
+func NewNullOptional[T any]() Optional[T] {
+	return Optional[T]{set: true, null: true}
+}
+
+// IsSet returns true if the Optional holds a value or an explicit null, and false if it is absent.
+func (o Optional[T]) IsSet() bool {
+	return o.set
+}
+
+// IsNull returns true if the Optional holds an explicit null.
+func (o Optional[T]) IsNull() bool {
+	return o.set && o.null
+}
+
+// Value returns the value held by the Optional and a boolean indicating if it holds one, rather than being absent or null.
+func (o Optional[T]) Value() (T, bool) {
+	if !o.set || o.null {
+		var zero T
+		return zero, false
+	}
+	return o.value, true
+}
+
+// ValueOr returns the value held by the Optional, or fallback if it is absent or null.
+func (o Optional[T]) ValueOr(fallback T) T {
+	if value, ok := o.Value(); ok {
+		return value
+	}
+	return fallback
+}
+
+// IsZero returns true if the Optional is absent, so that absent fields are omitted by `omitzero` and yaml `omitempty`.
+func (o Optional[T]) IsZero() bool {
+	return !o.set
+}
+
+// Set makes the Optional hold the provided value.
+func (o *Optional[T]) Set(value T) {
+	*o = NewOptional(value)
+}
+
+// SetNull makes the Optional hold an explicit null.
+func (o *Optional[T]) SetNull() {
+	*o = NewNullOptional[T]()
+}
+
+// Unset makes the Optional absent.
+func (o *Optional[T]) Unset() {
+	*o = Optional[T]{}
+}
+
+// ToPtr returns the value held by the Optional as a Ptr, which is nil if the Optional is absent or null.
+func (o Optional[T]) ToPtr() Ptr[T] {
+	value, ok := o.Value()
+	if !ok {
+		return NewNilPtr[T]()
+	}
+	return NewPtr(&value)
+}
+
+// Clone returns a deep copy of the Optional, holding a deep copy of its value.
+func (o Optional[T]) Clone() Optional[T] {
+	return DeepCopy(o)
+}
+
+// UnmarshalJSON unmarshals JSON data into the Optional. It is only called for present fields, which become
+// null or hold a value; missing fields stay absent.
+func (o *Optional[T]) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		o.SetNull()
+		return nil
+	}
+
+	var value T
+	if err := json.Unmarshal(data, &value); err != nil {
+		return err
+	}
+	o.Set(value)
+	return nil
+}
+
+// MarshalJSON marshals the Optional into JSON. Null and absent values serialize as "null".
+func (o Optional[T]) MarshalJSON() ([]byte, error) {
+	if value, ok := o.Value(); ok {
+		return json.Marshal(value)
+	}
+	return []byte("null"), nil
+}
+
+// UnmarshalXML unmarshals XML data into the Optional. An element with a nil="true" attribute, in any namespace
+// such as xsi:nil, becomes null; any other element holds a value. Missing elements stay absent.
+func (o *Optional[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for _, attr := range start.Attr {
+		if attr.Name.Local == "nil" && attr.Value == "true" {
+			o.SetNull()
+			return d.Skip()
+		}
+	}
+
+	var value T
+	if err := d.DecodeElement(&value, &start); err != nil {
+		return err
+	}
+	o.Set(value)
+	return nil
+}
+
+// MarshalXML marshals the Optional into XML. Absent values write nothing and null values write an empty element
+// with a nil="true" attribute.
+func (o Optional[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	switch {
+	case !o.set:
+		return nil
+	case o.null:
+		start.Attr = append(start.Attr, xml.Attr{Name: xml.Name{Local: "nil"}, Value: "true"})
+		if err := e.EncodeToken(start); err != nil {
+			return err
+		}
+		return e.EncodeToken(start.End())
+	}
+	return e.EncodeElement(o.value, start)
+}
+
+// UnmarshalYAML unmarshals a non-null YAML value into the Optional, which holds a value afterwards.
+// It is never called for null values, see Optional.
+func (o *Optional[T]) UnmarshalYAML(node *yaml.Node) error {
+	var value T
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	o.Set(value)
+	return nil
+}
+
+// MarshalYAML marshals the Optional into YAML. Null and absent values serialize as null.
+func (o Optional[T]) MarshalYAML() (any, error) {
+	if value, ok := o.Value(); ok {
+		return value, nil
+	}
+	return nil, nil
+}
+
+// optionalState returns the state of the Optional for reflection-based helpers such as MergePatch.
+func (o Optional[T]) optionalState() (set, null bool, value reflect.Value) {
+	return o.set, o.null, reflect.ValueOf(&o.value).Elem()
+}
+
+// setReflectValue makes the Optional hold the provided value, or null if it is invalid, and reports
+// whether the value has a compatible type.
+func (o *Optional[T]) setReflectValue(value reflect.Value) bool {
+	if !value.IsValid() {
+		o.SetNull()
+		return true
+	}
+	var target T
+	if !assignReflectValue(reflect.ValueOf(&target).Elem(), value) {
+		return false
+	}
+	o.Set(target)
+	return true
+}
+
+// cloneReflect returns a deep copy of the Optional for DeepCopy, which cannot reach its unexported fields.
+func (o Optional[T]) cloneReflect(c *cloneState) reflect.Value {
+	copied := o
+	copied.value = cloneValue(c, o.value)
+	return reflect.ValueOf(copied)
+}
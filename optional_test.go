@@ -0,0 +1,102 @@
+package wrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
+)
+
+type optionalUpdate struct {
+	Name  Optional[string] `json:"name,omitzero" xml:"name" yaml:"name,omitempty"`
+	Email Optional[string] `json:"email,omitzero" xml:"email" yaml:"email,omitempty"`
+	Age   Optional[int]    `json:"age,omitzero" xml:"age" yaml:"age,omitempty"`
+}
+
+func TestOptional_States(t *testing.T) {
+	var o Optional[int]
+	assert.False(t, o.IsSet())
+	assert.False(t, o.IsNull())
+	assert.True(t, o.IsZero())
+	_, ok := o.Value()
+	assert.False(t, ok)
+	assert.Equal(t, 5, o.ValueOr(5))
+	assert.Nil(t, o.ToPtr().X)
+
+	o.SetNull()
+	assert.True(t, o.IsSet())
+	assert.True(t, o.IsNull())
+	assert.False(t, o.IsZero())
+	_, ok = o.Value()
+	assert.False(t, ok)
+
+	o.Set(0)
+	assert.True(t, o.IsSet())
+	assert.False(t, o.IsNull())
+	value, ok := o.Value()
+	assert.True(t, ok)
+	assert.Equal(t, 0, value)
+	assert.Equal(t, 0, o.ValueOr(5))
+	ptr := o.ToPtr()
+	assert.Equal(t, 0, *ptr.Unwrap())
+
+	o.Unset()
+	assert.Equal(t, Optional[int]{}, o)
+	assert.Equal(t, NewOptional(1), Optional[int]{value: 1, set: true})
+	assert.True(t, NewNullOptional[int]().IsNull())
+}
+
+func TestOptional_JSON(t *testing.T) {
+	var update optionalUpdate
+	err := json.Unmarshal([]byte(`{"name":"ann","email":null}`), &update)
+	assert.NoError(t, err)
+	assert.Equal(t, NewOptional("ann"), update.Name)
+	assert.Equal(t, NewNullOptional[string](), update.Email)
+	assert.False(t, update.Age.IsSet())
+
+	data, err := json.Marshal(update)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"name":"ann","email":null}`, string(data))
+
+	err = json.Unmarshal([]byte(`{"age":"old"}`), &update)
+	assert.Error(t, err)
+}
+
+func TestOptional_XML(t *testing.T) {
+	update := optionalUpdate{Name: NewOptional("ann"), Email: NewNullOptional[string]()}
+	data, err := xml.Marshal(update)
+	assert.NoError(t, err)
+	assert.Equal(t, `<optionalUpdate><name>ann</name><email nil="true"></email></optionalUpdate>`, string(data))
+
+	var decoded optionalUpdate
+	err = xml.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, update, decoded)
+
+	err = xml.Unmarshal([]byte(`<u xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><name></name><age xsi:nil="true"/></u>`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, NewOptional(""), decoded.Name, "An empty element holds an empty value")
+	assert.True(t, decoded.Age.IsNull())
+}
+
+func TestOptional_YAML(t *testing.T) {
+	update := optionalUpdate{Name: NewOptional("ann"), Email: NewNullOptional[string]()}
+	data, err := yaml.Marshal(update)
+	assert.NoError(t, err)
+	assert.Equal(t, "name: ann\nemail: null\n", string(data))
+
+	var decoded optionalUpdate
+	err = yaml.Unmarshal([]byte("name: ann\nage: 30\n"), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, NewOptional("ann"), decoded.Name)
+	assert.Equal(t, NewOptional(30), decoded.Age)
+	assert.False(t, decoded.Email.IsSet())
+
+	// yaml.v3 never calls unmarshalers for null nodes, so YAML cannot express an explicit null.
+	decoded = optionalUpdate{}
+	err = yaml.Unmarshal([]byte("email: null\n"), &decoded)
+	assert.NoError(t, err)
+	assert.False(t, decoded.Email.IsSet())
+}
@@ -0,0 +1,368 @@
+package wrap
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"reflect"
+)
+
+// OrderedMap is a generic map with keys of type K and values of type V that remembers insertion order.
+// Keys, Values, Entries, iteration and the JSON/XML encodings all follow that order.
+// The zero value is an empty map ready to use.
+type OrderedMap[K comparable, V any] struct {
+	index map[K]*orderedNode[K, V]
+	head  *orderedNode[K, V]
+	tail  *orderedNode[K, V]
+}
+
+// orderedNode is an element of the doubly linked list holding the OrderedMap entries.
+type orderedNode[K comparable, V any] struct {
+	entry MapEntry[K, V]
+	prev  *orderedNode[K, V]
+	next  *orderedNode[K, V]
+}
+
+// NewOrderedMap creates a new OrderedMap instance holding the provided entries, in order.
+func NewOrderedMap[K comparable, V any](entries ...MapEntry[K, V]) *OrderedMap[K, V] {
+	m := &OrderedMap[K, V]{}
+	for _, entry := range entries {
+		m.Set(entry.Key, entry.Value)
+	}
+	return m
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (m *OrderedMap[K, V]) Get(key K) (V, bool) {
+	var zero V
+	node, exists := m.index[key]
+	if !exists {
+		return zero, false
+	}
+	return node.entry.Value, true
+}
+
+// Set adds or updates the value for the specified key and returns the OrderedMap instance.
+// New keys are added at the back; existing keys keep their position.
+func (m *OrderedMap[K, V]) Set(key K, value V) *OrderedMap[K, V] {
+	if node, exists := m.index[key]; exists {
+		node.entry.Value = value
+		return m
+	}
+	m.linkAfter(m.newNode(key, value), m.tail)
+	return m
+}
+
+// Delete removes the key-value pair associated with the specified key and returns the OrderedMap instance.
+func (m *OrderedMap[K, V]) Delete(key K) *OrderedMap[K, V] {
+	if node, exists := m.index[key]; exists {
+		m.unlink(node)
+		delete(m.index, key)
+	}
+	return m
+}
+
+// Contains checks if the specified key exists in the map.
+func (m *OrderedMap[K, V]) Contains(key K) bool {
+	_, exists := m.index[key]
+	return exists
+}
+
+// Keys returns a slice of all keys in the map, in order.
+func (m *OrderedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, len(m.index))
+	for node := m.head; node != nil; node = node.next {
+		keys = append(keys, node.entry.Key)
+	}
+	return keys
+}
+
+// Values returns a slice of all values in the map, in order.
+func (m *OrderedMap[K, V]) Values() Slice[V] {
+	values := make([]V, 0, len(m.index))
+	for node := m.head; node != nil; node = node.next {
+		values = append(values, node.entry.Value)
+	}
+	return NewSlice(values)
+}
+
+// Entries returns a slice of all key-value pairs in the map, in order.
+func (m *OrderedMap[K, V]) Entries() []MapEntry[K, V] {
+	entries := make([]MapEntry[K, V], 0, len(m.index))
+	for node := m.head; node != nil; node = node.next {
+		entries = append(entries, node.entry)
+	}
+	return entries
+}
+
+// Find returns the key of the first value, in order, that satisfies the provided comparison function, or zero value and false if not found.
+func (m *OrderedMap[K, V]) Find(compare func(V) bool) (K, bool) {
+	var zero K
+	for node := m.head; node != nil; node = node.next {
+		if compare(node.entry.Value) {
+			return node.entry.Key, true
+		}
+	}
+	return zero, false
+}
+
+// First returns the first key-value pair in the map, or false if the map is empty.
+func (m *OrderedMap[K, V]) First() (MapEntry[K, V], bool) {
+	if m.head == nil {
+		return MapEntry[K, V]{}, false
+	}
+	return m.head.entry, true
+}
+
+// Last returns the last key-value pair in the map, or false if the map is empty.
+func (m *OrderedMap[K, V]) Last() (MapEntry[K, V], bool) {
+	if m.tail == nil {
+		return MapEntry[K, V]{}, false
+	}
+	return m.tail.entry, true
+}
+
+// Len returns the number of key-value pairs in the map.
+func (m *OrderedMap[K, V]) Len() int {
+	return len(m.index)
+}
+
+// IsEmpty returns true if the map is empty, otherwise false.
+func (m *OrderedMap[K, V]) IsEmpty() bool {
+	return len(m.index) == 0
+}
+
+// Clear removes all key-value pairs from the map.
+func (m *OrderedMap[K, V]) Clear() {
+	m.index = nil
+	m.head = nil
+	m.tail = nil
+}
+
+// MoveToFront moves the specified key to the front of the map and returns whether the key exists.
+func (m *OrderedMap[K, V]) MoveToFront(key K) bool {
+	node, exists := m.index[key]
+	if !exists {
+		return false
+	}
+	m.unlink(node)
+	m.linkAfter(node, nil)
+	return true
+}
+
+// MoveToBack moves the specified key to the back of the map and returns whether the key exists.
+func (m *OrderedMap[K, V]) MoveToBack(key K) bool {
+	node, exists := m.index[key]
+	if !exists {
+		return false
+	}
+	m.unlink(node)
+	m.linkAfter(node, m.tail)
+	return true
+}
+
+// InsertBefore sets the value for the key and places it right before mark. An existing key is moved.
+// It returns false, leaving the map unchanged, if mark does not exist.
+func (m *OrderedMap[K, V]) InsertBefore(mark K, key K, value V) bool {
+	markNode, exists := m.index[mark]
+	if !exists {
+		return false
+	}
+	if key == mark {
+		markNode.entry.Value = value
+		return true
+	}
+	node := m.detachOrCreate(key, value)
+	m.linkAfter(node, markNode.prev)
+	return true
+}
+
+// InsertAfter sets the value for the key and places it right after mark. An existing key is moved.
+// It returns false, leaving the map unchanged, if mark does not exist.
+func (m *OrderedMap[K, V]) InsertAfter(mark K, key K, value V) bool {
+	markNode, exists := m.index[mark]
+	if !exists {
+		return false
+	}
+	if key == mark {
+		markNode.entry.Value = value
+		return true
+	}
+	node := m.detachOrCreate(key, value)
+	m.linkAfter(node, markNode)
+	return true
+}
+
+// Iter returns a lazy sequence over the key-value pairs of the map, in order.
+func (m *OrderedMap[K, V]) Iter() Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for node := m.head; node != nil; node = node.next {
+			if !yield(node.entry.Key, node.entry.Value) {
+				return
+			}
+		}
+	}
+}
+
+// ToMap returns a new Map holding the key-value pairs of the map.
+func (m *OrderedMap[K, V]) ToMap() Map[K, V] {
+	copied := make(map[K]V, len(m.index))
+	for node := m.head; node != nil; node = node.next {
+		copied[node.entry.Key] = node.entry.Value
+	}
+	return NewMap(copied)
+}
+
+// newNode creates a node for the key and registers it in the index.
+func (m *OrderedMap[K, V]) newNode(key K, value V) *orderedNode[K, V] {
+	if m.index == nil {
+		m.index = make(map[K]*orderedNode[K, V])
+	}
+	node := &orderedNode[K, V]{entry: MapEntry[K, V]{Key: key, Value: value}}
+	m.index[key] = node
+	return node
+}
+
+// detachOrCreate returns the node for the key with its value updated, unlinked from the list if it already existed.
+func (m *OrderedMap[K, V]) detachOrCreate(key K, value V) *orderedNode[K, V] {
+	node, exists := m.index[key]
+	if !exists {
+		return m.newNode(key, value)
+	}
+	node.entry.Value = value
+	m.unlink(node)
+	return node
+}
+
+// linkAfter links node right after prev, or at the front if prev is nil.
+func (m *OrderedMap[K, V]) linkAfter(node, prev *orderedNode[K, V]) {
+	node.prev = prev
+	if prev == nil {
+		node.next = m.head
+		m.head = node
+	} else {
+		node.next = prev.next
+		prev.next = node
+	}
+	if node.next == nil {
+		m.tail = node
+	} else {
+		node.next.prev = node
+	}
+}
+
+// unlink removes node from the list, keeping it in the index.
+func (m *OrderedMap[K, V]) unlink(node *orderedNode[K, V]) {
+	if node.prev == nil {
+		m.head = node.next
+	} else {
+		node.prev.next = node.next
+	}
+	if node.next == nil {
+		m.tail = node.prev
+	} else {
+		node.next.prev = node.prev
+	}
+	node.prev = nil
+	node.next = nil
+}
+
+// UnmarshalJSON unmarshals JSON data into the OrderedMap, keeping the order of the keys in the JSON object.
+func (m *OrderedMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.Clear()
+	return decodeJSONObject(data, func(key K, value V) {
+		m.Set(key, value)
+	})
+}
+
+// MarshalJSON marshals the OrderedMap into JSON. It produces a JSON object with keys in order.
+func (m OrderedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return encodeJSONObject(m.Entries())
+}
+
+// UnmarshalXML unmarshals XML data into the OrderedMap, keeping the document order of the entries.
+func (m *OrderedMap[K, V]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	m.Clear()
+	return unmarshalXMLEntries(d, func(key K, value V) {
+		m.Set(key, value)
+	})
+}
+
+// MarshalXML marshals the OrderedMap into XML, using the same entry layout as Map, with entries in order.
+func (m OrderedMap[K, V]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLEntries(e, start, m.Entries(), XMLKeyAttr{})
+}
+
+// encodeJSONObject writes the entries, in order, as a JSON object.
+func encodeJSONObject[K comparable, V any](entries []MapEntry[K, V]) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, entry := range entries {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		key, err := formatMapKey(entry.Key)
+		if err != nil {
+			return nil, err
+		}
+		encodedKey, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		encodedValue, err := json.Marshal(entry.Value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(encodedKey)
+		buf.WriteByte(':')
+		buf.Write(encodedValue)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeJSONObject decodes a JSON object, calling set for each member in document order. A JSON null decodes to no members.
+func decodeJSONObject[K comparable, V any](data []byte, set func(K, V)) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	token, err := decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token == nil {
+		return nil
+	}
+	if delim, ok := token.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("wrap: cannot unmarshal %v into an ordered map: expected a JSON object", token)
+	}
+
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		key, err := parseMapKey[K](token.(string))
+		if err != nil {
+			return err
+		}
+
+		var value V
+		if err := decoder.Decode(&value); err != nil {
+			return err
+		}
+		set(key, value)
+	}
+
+	_, err = decoder.Token()
+	return err
+}
+
+// cloneReflect returns a deep copy of the OrderedMap for DeepCopy, linking new nodes in the same order.
+func (m *OrderedMap[K, V]) cloneReflect(c *cloneState) reflect.Value {
+	copied := NewOrderedMap[K, V]()
+	for node := m.head; node != nil; node = node.next {
+		copied.Set(node.entry.Key, cloneValue(c, node.entry.Value))
+	}
+	return reflect.ValueOf(copied).Elem()
+}
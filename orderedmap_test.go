@@ -0,0 +1,148 @@
+package wrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderedMap_SetAndOrder(t *testing.T) {
+	var m OrderedMap[string, int]
+	m.Set("c", 3).Set("a", 1).Set("b", 2)
+	m.Set("c", 30) // existing keys keep their position
+
+	assert.Equal(t, []string{"c", "a", "b"}, m.Keys())
+	values := m.Values()
+	assert.Equal(t, []int{30, 1, 2}, values.Unwrap())
+	assert.Equal(t, []MapEntry[string, int]{{"c", 30}, {"a", 1}, {"b", 2}}, m.Entries())
+
+	value, ok := m.Get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 30, value)
+
+	key, ok := m.Find(func(v int) bool { return v < 10 })
+	assert.True(t, ok)
+	assert.Equal(t, "a", key, "Find should return the first match in order")
+
+	m.Delete("a")
+	assert.Equal(t, []string{"c", "b"}, m.Keys())
+	assert.False(t, m.Contains("a"))
+	assert.Equal(t, 2, m.Len())
+
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+	_, ok = m.First()
+	assert.False(t, ok)
+}
+
+func TestOrderedMap_Move(t *testing.T) {
+	tests := []struct {
+		name     string
+		apply    func(m *OrderedMap[string, int]) bool
+		expected []string
+		ok       bool
+	}{
+		{"MoveToFront", func(m *OrderedMap[string, int]) bool { return m.MoveToFront("c") }, []string{"c", "a", "b"}, true},
+		{"MoveToBack", func(m *OrderedMap[string, int]) bool { return m.MoveToBack("a") }, []string{"b", "c", "a"}, true},
+		{"MoveMissing", func(m *OrderedMap[string, int]) bool { return m.MoveToFront("x") }, []string{"a", "b", "c"}, false},
+		{"InsertBeforeNew", func(m *OrderedMap[string, int]) bool { return m.InsertBefore("b", "x", 9) }, []string{"a", "x", "b", "c"}, true},
+		{"InsertBeforeHead", func(m *OrderedMap[string, int]) bool { return m.InsertBefore("a", "x", 9) }, []string{"x", "a", "b", "c"}, true},
+		{"InsertAfterExisting", func(m *OrderedMap[string, int]) bool { return m.InsertAfter("c", "a", 9) }, []string{"b", "c", "a"}, true},
+		{"InsertAfterTail", func(m *OrderedMap[string, int]) bool { return m.InsertAfter("c", "x", 9) }, []string{"a", "b", "c", "x"}, true},
+		{"InsertMissingMark", func(m *OrderedMap[string, int]) bool { return m.InsertAfter("z", "x", 9) }, []string{"a", "b", "c"}, false},
+		{"InsertSelf", func(m *OrderedMap[string, int]) bool { return m.InsertBefore("b", "b", 9) }, []string{"a", "b", "c"}, true},
+	}
+
+	for _, tt := range tests {
+		m := NewOrderedMap(MapEntry[string, int]{"a", 1}, MapEntry[string, int]{"b", 2}, MapEntry[string, int]{"c", 3})
+		assert.Equal(t, tt.ok, tt.apply(m), tt.name)
+		assert.Equal(t, tt.expected, m.Keys(), tt.name)
+
+		first, _ := m.First()
+		last, _ := m.Last()
+		assert.Equal(t, tt.expected[0], first.Key, tt.name)
+		assert.Equal(t, tt.expected[len(tt.expected)-1], last.Key, tt.name)
+	}
+}
+
+func TestOrderedMap_JSON(t *testing.T) {
+	input := `{"zeta":1,"alpha":2,"mid":3}`
+
+	var m OrderedMap[string, int]
+	err := json.Unmarshal([]byte(input), &m)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"zeta", "alpha", "mid"}, m.Keys())
+
+	data, err := json.Marshal(&m)
+	assert.NoError(t, err)
+	assert.Equal(t, input, string(data), "Encoding should keep insertion order")
+
+	var ints OrderedMap[int, string]
+	err = json.Unmarshal([]byte(`{"3":"c","1":"a"}`), &ints)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{3, 1}, ints.Keys())
+
+	err = json.Unmarshal([]byte(`[1,2]`), &m)
+	assert.Error(t, err)
+
+	err = json.Unmarshal([]byte(`null`), &m)
+	assert.NoError(t, err)
+	assert.True(t, m.IsEmpty())
+
+	empty := NewOrderedMap[string, int]()
+	data, err = json.Marshal(empty)
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+}
+
+func TestOrderedMap_XML(t *testing.T) {
+	type Test struct {
+		XMLName xml.Name                 `xml:"test"`
+		Limits  *OrderedMap[string, int] `xml:"limits"`
+	}
+
+	m := NewOrderedMap(MapEntry[string, int]{"b", 2}, MapEntry[string, int]{"a", 1})
+	data, err := xml.Marshal(Test{Limits: m})
+	assert.NoError(t, err)
+	assert.Equal(t, `<test><limits><entry key="b">2</entry><entry key="a">1</entry></limits></test>`, string(data))
+
+	var decoded Test
+	err = xml.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"b", "a"}, decoded.Limits.Keys())
+}
+
+func TestOrderedMap_MarshalByValue(t *testing.T) {
+	type Test struct {
+		XMLName xml.Name                `json:"-" xml:"test"`
+		Limits  OrderedMap[string, int] `json:"limits" xml:"limits"`
+	}
+
+	test := Test{}
+	test.Limits.Set("b", 2).Set("a", 1)
+
+	data, err := json.Marshal(test)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"limits":{"b":2,"a":1}}`, string(data))
+
+	data, err = xml.Marshal(test)
+	assert.NoError(t, err)
+	assert.Equal(t, `<test><limits><entry key="b">2</entry><entry key="a">1</entry></limits></test>`, string(data))
+
+	var decoded Test
+	err = json.Unmarshal([]byte(`{"limits":{"z":1,"y":2}}`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"z", "y"}, decoded.Limits.Keys())
+}
+
+func TestOrderedMap_IterAndToMap(t *testing.T) {
+	m := NewOrderedMap(MapEntry[string, int]{"b", 2}, MapEntry[string, int]{"a", 1})
+
+	keys := m.Iter().Keys().Collect()
+	assert.Equal(t, []string{"b", "a"}, keys.Unwrap())
+
+	plain := m.ToMap()
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, plain.Unwrap())
+}
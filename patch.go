@@ -0,0 +1,387 @@
+package wrap
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// PatchOp is the operation of a JSON Patch (RFC 6902) operation.
+type PatchOp string
+
+// The operations defined by RFC 6902.
+const (
+	PatchAdd     PatchOp = "add"
+	PatchRemove  PatchOp = "remove"
+	PatchReplace PatchOp = "replace"
+	PatchMove    PatchOp = "move"
+	PatchCopy    PatchOp = "copy"
+	PatchTest    PatchOp = "test"
+)
+
+var (
+	// ErrPathNotFound is returned when a JSON Pointer of a patch operation does not exist in the document.
+	ErrPathNotFound = errors.New("wrap: path not found")
+	// ErrPatchTestFailed is returned when the value of a test operation does not match the document.
+	ErrPatchTestFailed = errors.New("wrap: test failed")
+)
+
+// PatchOperation is a single operation of a JSON Patch. Path and From are JSON Pointers (RFC 6901).
+type PatchOperation struct {
+	Op    PatchOp `json:"op"`
+	Path  string  `json:"path"`
+	From  string  `json:"from,omitempty"`
+	Value any     `json:"value,omitempty"`
+}
+
+// Patch is a JSON Patch document (RFC 6902): a list of operations applied in order.
+type Patch []PatchOperation
+
+// PatchError is returned when a patch operation fails. Path is the JSON Pointer that caused the failure,
+// which is the From pointer when the source of a move or copy operation is missing.
+type PatchError struct {
+	Index int
+	Op    PatchOp
+	Path  string
+	Err   error
+}
+
+// Error returns the error message, including the failing operation and JSON Pointer.
+func (e *PatchError) Error() string {
+	return fmt.Sprintf("wrap: patch operation %d (%s) failed at %q: %v", e.Index, e.Op, e.Path, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *PatchError) Unwrap() error {
+	return e.Err
+}
+
+// Apply applies the patch to the JSON representation of the value pointed to by target and stores the result
+// back into it. The patch is applied as a whole: if any operation fails, target is left unchanged.
+func (p Patch) Apply(target any) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("wrap: cannot apply patch to non-pointer %T", target)
+	}
+
+	doc, err := toJSONTree(target)
+	if err != nil {
+		return err
+	}
+	for i, operation := range p {
+		if doc, err = operation.apply(doc); err != nil {
+			err.(*PatchError).Index = i
+			return err
+		}
+	}
+
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	patched := reflect.New(rv.Elem().Type())
+	if err := json.Unmarshal(data, patched.Interface()); err != nil {
+		return fmt.Errorf("wrap: cannot apply patch: %w", err)
+	}
+	rv.Elem().Set(patched.Elem())
+	return nil
+}
+
+// ApplyPatch applies a JSON Patch to the Map. If any operation fails, the Map is left unchanged.
+func (m *Map[K, V]) ApplyPatch(patch Patch) error {
+	return patch.Apply(m)
+}
+
+// ApplyPatch applies a JSON Patch to the Slice. If any operation fails, the Slice is left unchanged.
+func (s *Slice[T]) ApplyPatch(patch Patch) error {
+	return patch.Apply(s)
+}
+
+// ApplyPatch applies a JSON Patch to the Object. If any operation fails, the Object is left unchanged.
+func (o *Object) ApplyPatch(patch Patch) error {
+	return patch.Apply(o)
+}
+
+// MarshalJSON marshals the operation into JSON. The value is always present for add, replace and test operations.
+func (o PatchOperation) MarshalJSON() ([]byte, error) {
+	fields := struct {
+		Op    PatchOp `json:"op"`
+		Path  string  `json:"path"`
+		From  *string `json:"from,omitempty"`
+		Value *any    `json:"value,omitempty"`
+	}{Op: o.Op, Path: o.Path}
+	switch o.Op {
+	case PatchAdd, PatchReplace, PatchTest:
+		fields.Value = &o.Value
+	case PatchMove, PatchCopy:
+		fields.From = &o.From
+	}
+	return json.Marshal(fields)
+}
+
+// UnmarshalJSON unmarshals JSON data into the operation, checking that the members required by its op are present.
+func (o *PatchOperation) UnmarshalJSON(data []byte) error {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+
+	var decoded PatchOperation
+	if err := json.Unmarshal(fields["op"], &decoded.Op); err != nil {
+		return fmt.Errorf("wrap: invalid patch operation: %w", err)
+	}
+	required := []string{"path"}
+	switch decoded.Op {
+	case PatchAdd, PatchReplace, PatchTest:
+		required = append(required, "value")
+	case PatchMove, PatchCopy:
+		required = append(required, "from")
+	case PatchRemove:
+	default:
+		return fmt.Errorf("wrap: invalid patch operation %q", decoded.Op)
+	}
+	for _, name := range required {
+		if _, ok := fields[name]; !ok {
+			return fmt.Errorf("wrap: patch operation %q is missing %q", decoded.Op, name)
+		}
+	}
+
+	if err := json.Unmarshal(fields["path"], &decoded.Path); err != nil {
+		return fmt.Errorf("wrap: invalid patch path: %w", err)
+	}
+	if raw, ok := fields["from"]; ok {
+		if err := json.Unmarshal(raw, &decoded.From); err != nil {
+			return fmt.Errorf("wrap: invalid patch from: %w", err)
+		}
+	}
+	if raw, ok := fields["value"]; ok {
+		value, err := toJSONTree(raw)
+		if err != nil {
+			return err
+		}
+		decoded.Value = value
+	}
+	*o = decoded
+	return nil
+}
+
+// apply applies the operation to a generic JSON document and returns the updated document.
+func (o PatchOperation) apply(doc any) (any, error) {
+	fail := func(path string, err error) (any, error) {
+		return nil, &PatchError{Op: o.Op, Path: path, Err: err}
+	}
+
+	path, err := parseJSONPointer(o.Path)
+	if err != nil {
+		return fail(o.Path, err)
+	}
+
+	var value any
+	switch o.Op {
+	case PatchAdd, PatchReplace, PatchTest:
+		if value, err = toJSONTree(o.Value); err != nil {
+			return fail(o.Path, err)
+		}
+	case PatchMove, PatchCopy:
+		from, err := parseJSONPointer(o.From)
+		if err != nil {
+			return fail(o.From, err)
+		}
+		if value, err = getJSONPointer(doc, from); err != nil {
+			return fail(o.From, err)
+		}
+		if o.Op == PatchMove {
+			if o.From == o.Path {
+				return doc, nil
+			}
+			if strings.HasPrefix(o.Path, o.From+"/") {
+				return fail(o.Path, errors.New("cannot move a value into one of its children"))
+			}
+			if doc, err = updateJSONPointer(doc, from, removeJSONValue); err != nil {
+				return fail(o.From, err)
+			}
+		} else if value, err = toJSONTree(value); err != nil {
+			return fail(o.From, err)
+		}
+	case PatchRemove:
+	default:
+		return fail(o.Path, fmt.Errorf("invalid operation %q", o.Op))
+	}
+
+	switch {
+	case len(path) == 0 && o.Op != PatchTest:
+		// The operation targets the whole document.
+		if o.Op == PatchRemove {
+			value = nil
+		}
+		doc = value
+	case o.Op == PatchAdd || o.Op == PatchMove || o.Op == PatchCopy:
+		doc, err = updateJSONPointer(doc, path, func(container any, token string) (any, error) {
+			return addJSONValue(container, token, value)
+		})
+	case o.Op == PatchRemove:
+		doc, err = updateJSONPointer(doc, path, removeJSONValue)
+	case o.Op == PatchReplace:
+		doc, err = updateJSONPointer(doc, path, func(container any, token string) (any, error) {
+			return replaceJSONValue(container, token, value)
+		})
+	case o.Op == PatchTest:
+		var current any
+		if current, err = getJSONPointer(doc, path); err == nil && !jsonTreeEqual(current, value) {
+			err = ErrPatchTestFailed
+		}
+	}
+	if err != nil {
+		return fail(o.Path, err)
+	}
+	return doc, nil
+}
+
+// jsonPointerEscaper and jsonPointerUnescaper convert keys to and from JSON Pointer reference tokens.
+var (
+	jsonPointerEscaper   = strings.NewReplacer("~", "~0", "/", "~1")
+	jsonPointerUnescaper = strings.NewReplacer("~1", "/", "~0", "~")
+)
+
+// parseJSONPointer splits a JSON Pointer into its unescaped reference tokens.
+func parseJSONPointer(pointer string) ([]string, error) {
+	if pointer == "" {
+		return nil, nil
+	}
+	if !strings.HasPrefix(pointer, "/") {
+		return nil, errors.New("JSON pointer must start with '/'")
+	}
+	tokens := strings.Split(pointer[1:], "/")
+	for i, token := range tokens {
+		tokens[i] = jsonPointerUnescaper.Replace(token)
+	}
+	return tokens, nil
+}
+
+// escapeJSONPointer escapes a key to be used as a JSON Pointer reference token.
+func escapeJSONPointer(key string) string {
+	return jsonPointerEscaper.Replace(key)
+}
+
+// getJSONPointer returns the value referenced by the tokens in a generic JSON document.
+func getJSONPointer(doc any, tokens []string) (any, error) {
+	current := doc
+	for _, token := range tokens {
+		child, err := childJSONValue(current, token)
+		if err != nil {
+			return nil, err
+		}
+		current = child
+	}
+	return current, nil
+}
+
+// updateJSONPointer calls update with the container holding the value referenced by the non-empty tokens and
+// the last token, storing the updated container back into the document.
+func updateJSONPointer(doc any, tokens []string, update func(container any, token string) (any, error)) (any, error) {
+	if len(tokens) == 1 {
+		return update(doc, tokens[0])
+	}
+	child, err := childJSONValue(doc, tokens[0])
+	if err != nil {
+		return nil, err
+	}
+	updated, err := updateJSONPointer(child, tokens[1:], update)
+	if err != nil {
+		return nil, err
+	}
+	return replaceJSONValue(doc, tokens[0], updated)
+}
+
+// childJSONValue returns the member or element of a container referenced by the token.
+func childJSONValue(container any, token string) (any, error) {
+	switch c := container.(type) {
+	case map[string]any:
+		value, ok := c[token]
+		if !ok {
+			return nil, ErrPathNotFound
+		}
+		return value, nil
+	case []any:
+		index, err := parseJSONIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return c[index], nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// addJSONValue adds a member to an object or inserts an element into an array.
+func addJSONValue(container any, token string, value any) (any, error) {
+	switch c := container.(type) {
+	case map[string]any:
+		c[token] = value
+		return c, nil
+	case []any:
+		if token == "-" {
+			return append(c, value), nil
+		}
+		index, err := parseJSONIndex(token, len(c))
+		if err != nil {
+			return nil, err
+		}
+		return slices.Insert(c, index, value), nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// removeJSONValue removes a member from an object or an element from an array.
+func removeJSONValue(container any, token string) (any, error) {
+	switch c := container.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok {
+			return nil, ErrPathNotFound
+		}
+		delete(c, token)
+		return c, nil
+	case []any:
+		index, err := parseJSONIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Delete(c, index, index+1), nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// replaceJSONValue replaces an existing member of an object or element of an array.
+func replaceJSONValue(container any, token string, value any) (any, error) {
+	switch c := container.(type) {
+	case map[string]any:
+		if _, ok := c[token]; !ok {
+			return nil, ErrPathNotFound
+		}
+		c[token] = value
+		return c, nil
+	case []any:
+		index, err := parseJSONIndex(token, len(c)-1)
+		if err != nil {
+			return nil, err
+		}
+		c[index] = value
+		return c, nil
+	}
+	return nil, ErrPathNotFound
+}
+
+// parseJSONIndex parses an array index reference token, which must not exceed max.
+func parseJSONIndex(token string, max int) (int, error) {
+	if token == "" || (len(token) > 1 && token[0] == '0') || strings.TrimLeft(token, "0123456789") != "" {
+		return 0, fmt.Errorf("invalid array index %q", token)
+	}
+	index, err := strconv.Atoi(token)
+	if err != nil || index > max {
+		return 0, ErrPathNotFound
+	}
+	return index, nil
+}
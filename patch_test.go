@@ -0,0 +1,131 @@
+package wrap
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPatch_Operations(t *testing.T) {
+	tests := []struct {
+		name     string
+		doc      string
+		patch    string
+		expected string
+	}{
+		{"add member", `{"a":1}`, `[{"op":"add","path":"/b","value":[1]}]`, `{"a":1,"b":[1]}`},
+		{"add replaces member", `{"a":1}`, `[{"op":"add","path":"/a","value":2}]`, `{"a":2}`},
+		{"add inserts element", `{"a":[1,3]}`, `[{"op":"add","path":"/a/1","value":2}]`, `{"a":[1,2,3]}`},
+		{"add appends element", `{"a":[1]}`, `[{"op":"add","path":"/a/-","value":2}]`, `{"a":[1,2]}`},
+		{"remove member", `{"a":1,"b":2}`, `[{"op":"remove","path":"/a"}]`, `{"b":2}`},
+		{"remove element", `{"a":[1,2,3]}`, `[{"op":"remove","path":"/a/1"}]`, `{"a":[1,3]}`},
+		{"replace", `{"a":{"b":1}}`, `[{"op":"replace","path":"/a/b","value":null}]`, `{"a":{"b":null}}`},
+		{"replace document", `{"a":1}`, `[{"op":"replace","path":"","value":{"b":2}}]`, `{"b":2}`},
+		{"move", `{"a":{"b":1},"c":[]}`, `[{"op":"move","from":"/a/b","path":"/c/0"}]`, `{"a":{},"c":[1]}`},
+		{"move to itself", `{"a":1}`, `[{"op":"move","from":"/a","path":"/a"}]`, `{"a":1}`},
+		{"copy", `{"a":{"b":1}}`, `[{"op":"copy","from":"/a","path":"/c"},{"op":"add","path":"/c/d","value":2}]`, `{"a":{"b":1},"c":{"b":1,"d":2}}`},
+		{"test", `{"a":[1,{"b":"x"}]}`, `[{"op":"test","path":"/a","value":[1.0,{"b":"x"}]}]`, `{"a":[1,{"b":"x"}]}`},
+		{"escaped keys", `{"a/b":1,"m~n":2}`, `[{"op":"remove","path":"/a~1b"},{"op":"replace","path":"/m~0n","value":3}]`, `{"m~n":3}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var object Object
+			assert.NoError(t, json.Unmarshal([]byte(tt.doc), &object))
+			var patch Patch
+			assert.NoError(t, json.Unmarshal([]byte(tt.patch), &patch))
+
+			assert.NoError(t, object.ApplyPatch(patch))
+			data, err := json.Marshal(object)
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(data))
+		})
+	}
+}
+
+func TestPatch_Errors(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch Patch
+		path  string
+		err   error
+	}{
+		{"missing member", Patch{{Op: PatchRemove, Path: "/missing"}}, "/missing", ErrPathNotFound},
+		{"missing parent", Patch{{Op: PatchAdd, Path: "/x/y", Value: 1}}, "/x/y", ErrPathNotFound},
+		{"index out of range", Patch{{Op: PatchAdd, Path: "/list/5", Value: 1}}, "/list/5", ErrPathNotFound},
+		{"replace missing", Patch{{Op: PatchReplace, Path: "/missing", Value: 1}}, "/missing", ErrPathNotFound},
+		{"missing from", Patch{{Op: PatchMove, From: "/missing", Path: "/a"}}, "/missing", ErrPathNotFound},
+		{"test failed", Patch{{Op: PatchTest, Path: "/a", Value: 2}}, "/a", ErrPatchTestFailed},
+		{"later operation", Patch{{Op: PatchAdd, Path: "/b", Value: 1}, {Op: PatchTest, Path: "/b", Value: 2}}, "/b", ErrPatchTestFailed},
+		{"invalid pointer", Patch{{Op: PatchRemove, Path: "a"}}, "a", nil},
+		{"invalid index", Patch{{Op: PatchRemove, Path: "/list/01"}}, "/list/01", nil},
+		{"move into child", Patch{{Op: PatchMove, From: "/list", Path: "/list/0"}}, "/list/0", nil},
+		{"invalid operation", Patch{{Op: "merge", Path: "/a"}}, "/a", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			o := NewObject(map[string]any{"a": 1, "list": []any{1, 2}})
+			err := o.ApplyPatch(tt.patch)
+
+			var patchErr *PatchError
+			assert.ErrorAs(t, err, &patchErr)
+			assert.Equal(t, tt.path, patchErr.Path)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+			}
+			assert.Equal(t, map[string]any{"a": 1, "list": []any{1, 2}}, o.X, "A failed patch must leave the target unchanged")
+		})
+	}
+}
+
+func TestPatch_TestLargeNumbers(t *testing.T) {
+	m := NewMap(map[string]int64{"id": 9007199254740993})
+
+	err := m.ApplyPatch(Patch{{Op: PatchTest, Path: "/id", Value: int64(9007199254740992)}})
+	assert.ErrorIs(t, err, ErrPatchTestFailed)
+	err = m.ApplyPatch(Patch{{Op: PatchTest, Path: "/id", Value: int64(9007199254740993)}})
+	assert.NoError(t, err)
+}
+
+func TestPatch_MapAndSlice(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1})
+	assert.NoError(t, m.ApplyPatch(Patch{
+		{Op: PatchAdd, Path: "/b", Value: 2},
+		{Op: PatchRemove, Path: "/a"},
+	}))
+	assert.Equal(t, map[string]int{"b": 2}, m.X)
+
+	err := m.ApplyPatch(Patch{{Op: PatchAdd, Path: "/c", Value: "text"}})
+	assert.Error(t, err, "The patched document must still fit the Map type")
+	assert.Equal(t, map[string]int{"b": 2}, m.X)
+
+	s := NewSlice([]string{"a", "c"})
+	assert.NoError(t, s.ApplyPatch(Patch{{Op: PatchAdd, Path: "/1", Value: "b"}}))
+	assert.Equal(t, []string{"a", "b", "c"}, s.X)
+
+	assert.Error(t, Patch{}.Apply(m), "The target must be a pointer")
+}
+
+func TestPatch_JSON(t *testing.T) {
+	var patch Patch
+	err := json.Unmarshal([]byte(`[{"op":"add","path":"/a","value":null},{"op":"copy","from":"","path":"/b"}]`), &patch)
+	assert.NoError(t, err)
+	assert.Equal(t, Patch{{Op: PatchAdd, Path: "/a"}, {Op: PatchCopy, Path: "/b"}}, patch)
+
+	data, err := json.Marshal(patch)
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"op":"add","path":"/a","value":null},{"op":"copy","path":"/b","from":""}]`, string(data))
+
+	invalid := []string{
+		`[{"op":"add","path":"/a"}]`,
+		`[{"op":"move","path":"/a"}]`,
+		`[{"op":"remove"}]`,
+		`[{"op":"merge","path":"/a"}]`,
+		`[{"path":"/a"}]`,
+	}
+	for _, data := range invalid {
+		assert.Error(t, json.Unmarshal([]byte(data), &patch), data)
+	}
+}
@@ -1,9 +1,13 @@
 package wrap
 
 import (
+	"database/sql"
+	"database/sql/driver"
 	"encoding/json"
 	"encoding/xml"
 	"fmt"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Ptr is a generic wrapper for a pointer of type T.
@@ -57,6 +61,11 @@ func (p *Ptr[T]) IsNil() bool {
 	return p.X == nil
 }
 
+// Clone returns a deep copy of the Ptr, pointing to a deep copy of its value.
+func (p Ptr[T]) Clone() Ptr[T] {
+	return DeepCopy(p)
+}
+
 // UnmarshalJSON unmarshals JSON data into the Ptr. It handles both "null" and non-null values.
 func (p *Ptr[T]) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
@@ -133,6 +142,50 @@ func (p Ptr[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 	return e.EncodeElement(*p.X, start)
 }
 
+// UnmarshalYAML unmarshals a non-null YAML value into the Ptr.
+// Unlike UnmarshalJSON, it is never called for null ("~", "null") values: yaml.v3 does not call unmarshalers for
+// null nodes and leaves struct fields such as Ptr unchanged. A null or missing key therefore leaves a freshly decoded
+// Ptr nil, but does not reset a Ptr that already holds a value; decode into a zero value to get JSON semantics.
+func (p *Ptr[T]) UnmarshalYAML(node *yaml.Node) error {
+	var value T
+	if err := node.Decode(&value); err != nil {
+		return err
+	}
+	p.X = &value
+	return nil
+}
+
+// MarshalYAML marshals the Ptr into YAML. If the pointer is nil, it serializes as null.
+func (p Ptr[T]) MarshalYAML() (any, error) {
+	if p.X == nil {
+		return nil, nil
+	}
+	return *p.X, nil
+}
+
+// Scan implements the sql.Scanner interface. A NULL value sets the pointer to nil.
+func (p *Ptr[T]) Scan(src any) error {
+	if src == nil {
+		p.X = nil
+		return nil
+	}
+
+	var value sql.Null[T]
+	if err := value.Scan(src); err != nil {
+		return err
+	}
+	p.X = &value.V
+	return nil
+}
+
+// Value implements the driver.Valuer interface. If the pointer is nil, it is stored as NULL.
+func (p Ptr[T]) Value() (driver.Value, error) {
+	if p.X == nil {
+		return nil, nil
+	}
+	return driver.DefaultParameterConverter.ConvertValue(*p.X)
+}
+
 // String returns a string representation of the Ptr, using the default formatting for its value.
 func (s Ptr[T]) String() string {
 	return fmt.Sprintf("%v", s.X)
@@ -8,6 +8,7 @@ import (
 	"github.com/twoojoo/wrap"
 
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestNewPtr(t *testing.T) {
@@ -137,4 +138,68 @@ func TestUnmarshalXML(t *testing.T) {
 	err = xml.Unmarshal([]byte("<Test><value></value></Test>"), &testStruct)
 	assert.NoError(t, err)
 	assert.True(t, testStruct.Value.IsNil())
+}
+
+func TestMarshalYAML(t *testing.T) {
+	type Test struct {
+		Value wrap.Ptr[int] `yaml:"value"`
+	}
+
+	value := 42
+	data, err := yaml.Marshal(Test{Value: wrap.NewPtr(&value)})
+	assert.NoError(t, err)
+	assert.Equal(t, "value: 42\n", string(data))
+
+	data, err = yaml.Marshal(Test{Value: wrap.NewNilPtr[int]()})
+	assert.NoError(t, err)
+	assert.Equal(t, "value: null\n", string(data))
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	type Test struct {
+		Value wrap.Ptr[int] `yaml:"value"`
+	}
+
+	tests := []struct {
+		input    string
+		expected *int
+	}{
+		{"value: 42", &[]int{42}[0]},
+		{"value: ~", nil},
+		{"value: null", nil},
+		{"other: 1", nil}, // missing key
+	}
+
+	for _, tt := range tests {
+		var testStruct Test
+		err := yaml.Unmarshal([]byte(tt.input), &testStruct)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, testStruct.Value.Unwrap())
+	}
+
+	// yaml.v3 never calls UnmarshalYAML for null nodes, so a null value leaves a set Ptr unchanged.
+	value := 1
+	testStruct := Test{Value: wrap.NewPtr(&value)}
+	err := yaml.Unmarshal([]byte("value: null"), &testStruct)
+	assert.NoError(t, err)
+	assert.Equal(t, &value, testStruct.Value.Unwrap())
+}
+
+func TestYAMLRoundTrip(t *testing.T) {
+	type Test struct {
+		Name  wrap.Ptr[string] `yaml:"name"`
+		Limit wrap.Ptr[int]    `yaml:"limit"`
+	}
+
+	name := "test"
+	data, err := yaml.Marshal(Test{Name: wrap.NewPtr(&name)})
+	assert.NoError(t, err)
+
+	var decoded Test
+	err = yaml.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	val, ok := decoded.Name.GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, "test", val)
+	assert.True(t, decoded.Limit.IsNil())
 }
\ No newline at end of file
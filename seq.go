@@ -0,0 +1,336 @@
+package wrap
+
+import (
+	"iter"
+)
+
+// Seq is a lazy sequence of values of type T. Stages such as Filter or Take only describe the pipeline;
+// nothing runs until a terminal operation such as Collect or Reduce is called. A Seq can be ranged over directly.
+type Seq[T any] func(yield func(T) bool)
+
+// Seq2 is a lazy sequence of pairs of type K and V, such as the entries of a Map. A Seq2 can be ranged over directly.
+type Seq2[K, V any] func(yield func(K, V) bool)
+
+// NewSeq creates a new Seq from the provided iter.Seq.
+func NewSeq[T any](seq iter.Seq[T]) Seq[T] {
+	return Seq[T](seq)
+}
+
+// NewSeq2 creates a new Seq2 from the provided iter.Seq2.
+func NewSeq2[K, V any](seq iter.Seq2[K, V]) Seq2[K, V] {
+	return Seq2[K, V](seq)
+}
+
+// Unwrap returns the sequence as an iter.Seq.
+func (s Seq[T]) Unwrap() iter.Seq[T] {
+	return iter.Seq[T](s)
+}
+
+// Filter returns a sequence containing only the values that satisfy the provided predicate function.
+func (s Seq[T]) Filter(predicate func(T) bool) Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if predicate(v) && !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns a sequence of the values transformed by the provided function. Use SeqMap to change the value type.
+func (s Seq[T]) Map(transform func(T) T) Seq[T] {
+	return SeqMap(s, transform)
+}
+
+// FlatMap returns a sequence of the values of every sequence produced by the provided function. Use SeqFlatMap to change the value type.
+func (s Seq[T]) FlatMap(transform func(T) Seq[T]) Seq[T] {
+	return SeqFlatMap(s, transform)
+}
+
+// Take returns a sequence of at most the first n values.
+func (s Seq[T]) Take(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for v := range s {
+			if !yield(v) {
+				return
+			}
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence without the first n values.
+func (s Seq[T]) Skip(n int) Seq[T] {
+	return func(yield func(T) bool) {
+		skipped := 0
+		for v := range s {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// TakeWhile returns a sequence of the leading values that satisfy the provided predicate function.
+func (s Seq[T]) TakeWhile(predicate func(T) bool) Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s {
+			if !predicate(v) || !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate returns a sequence of the values paired with their zero-based position.
+func (s Seq[T]) Enumerate() Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for v := range s {
+			if !yield(i, v) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Collect runs the sequence and returns its values as a new Slice.
+func (s Seq[T]) Collect() Slice[T] {
+	collected := []T{}
+	for v := range s {
+		collected = append(collected, v)
+	}
+	return NewSlice(collected)
+}
+
+// Reduce runs the sequence and combines its values, starting from initial. Use SeqReduce to change the result type.
+func (s Seq[T]) Reduce(initial T, combine func(T, T) T) T {
+	return SeqReduce(s, initial, combine)
+}
+
+// Count runs the sequence and returns the number of values.
+func (s Seq[T]) Count() int {
+	count := 0
+	for range s {
+		count++
+	}
+	return count
+}
+
+// Any returns true if a value satisfies the provided predicate function. It stops at the first match.
+func (s Seq[T]) Any(predicate func(T) bool) bool {
+	for v := range s {
+		if predicate(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// All returns true if every value satisfies the provided predicate function. It stops at the first mismatch.
+func (s Seq[T]) All(predicate func(T) bool) bool {
+	for v := range s {
+		if !predicate(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// First returns the first value of the sequence, or false if the sequence is empty.
+func (s Seq[T]) First() (T, bool) {
+	for v := range s {
+		return v, true
+	}
+	var zero T
+	return zero, false
+}
+
+// Unwrap returns the sequence as an iter.Seq2.
+func (s Seq2[K, V]) Unwrap() iter.Seq2[K, V] {
+	return iter.Seq2[K, V](s)
+}
+
+// Filter returns a sequence containing only the pairs that satisfy the provided predicate function.
+func (s Seq2[K, V]) Filter(predicate func(K, V) bool) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range s {
+			if predicate(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Take returns a sequence of at most the first n pairs.
+func (s Seq2[K, V]) Take(n int) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if n <= 0 {
+			return
+		}
+		taken := 0
+		for k, v := range s {
+			if !yield(k, v) {
+				return
+			}
+			taken++
+			if taken >= n {
+				return
+			}
+		}
+	}
+}
+
+// Skip returns a sequence without the first n pairs.
+func (s Seq2[K, V]) Skip(n int) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		skipped := 0
+		for k, v := range s {
+			if skipped < n {
+				skipped++
+				continue
+			}
+			if !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// Keys returns a sequence of the first element of each pair.
+func (s Seq2[K, V]) Keys() Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range s {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values returns a sequence of the second element of each pair.
+func (s Seq2[K, V]) Values() Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range s {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Count runs the sequence and returns the number of pairs.
+func (s Seq2[K, V]) Count() int {
+	count := 0
+	for range s {
+		count++
+	}
+	return count
+}
+
+// SeqMap returns a sequence of the values transformed by the provided function.
+func SeqMap[T, U any](s Seq[T], transform func(T) U) Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			if !yield(transform(v)) {
+				return
+			}
+		}
+	}
+}
+
+// SeqFlatMap returns a sequence of the values of every sequence produced by the provided function.
+func SeqFlatMap[T, U any](s Seq[T], transform func(T) Seq[U]) Seq[U] {
+	return func(yield func(U) bool) {
+		for v := range s {
+			for u := range transform(v) {
+				if !yield(u) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// SeqChunk returns a sequence of Slices holding consecutive runs of size values. The last chunk may be shorter.
+// It returns an empty sequence if size is not positive.
+func SeqChunk[T any](s Seq[T], size int) Seq[Slice[T]] {
+	return func(yield func(Slice[T]) bool) {
+		if size <= 0 {
+			return
+		}
+		chunk := make([]T, 0, size)
+		for v := range s {
+			chunk = append(chunk, v)
+			if len(chunk) == size {
+				if !yield(NewSlice(chunk)) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(NewSlice(chunk))
+		}
+	}
+}
+
+// SeqZip returns a sequence pairing the values of a and b. It stops when either sequence is exhausted.
+func SeqZip[T, U any](a Seq[T], b Seq[U]) Seq2[T, U] {
+	return func(yield func(T, U) bool) {
+		next, stop := iter.Pull(iter.Seq[U](b))
+		defer stop()
+		for t := range a {
+			u, ok := next()
+			if !ok || !yield(t, u) {
+				return
+			}
+		}
+	}
+}
+
+// SeqDistinct returns a sequence without repeated values, keeping the first occurrence of each.
+func SeqDistinct[T comparable](s Seq[T]) Seq[T] {
+	return func(yield func(T) bool) {
+		seen := make(map[T]struct{})
+		for v := range s {
+			if _, ok := seen[v]; ok {
+				continue
+			}
+			seen[v] = struct{}{}
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// SeqReduce runs the sequence and combines its values into an accumulator, starting from initial.
+func SeqReduce[T, A any](s Seq[T], initial A, combine func(A, T) A) A {
+	accumulator := initial
+	for v := range s {
+		accumulator = combine(accumulator, v)
+	}
+	return accumulator
+}
+
+// CollectMap runs the sequence and returns its pairs as a new Map. Later pairs overwrite earlier ones with the same key.
+func CollectMap[K comparable, V any](s Seq2[K, V]) Map[K, V] {
+	collected := make(map[K]V)
+	for k, v := range s {
+		collected[k] = v
+	}
+	return NewMap(collected)
+}
@@ -0,0 +1,120 @@
+package wrap
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSeq_Lazy(t *testing.T) {
+	s := NewSlice([]int{1, 2, 3, 4, 5, 6})
+
+	calls := 0
+	pipeline := s.Iter().
+		Filter(func(v int) bool { calls++; return v%2 == 0 }).
+		Map(func(v int) int { return v * 10 })
+	assert.Equal(t, 0, calls, "Stages should not run before a terminal operation")
+
+	first, ok := pipeline.First()
+	assert.True(t, ok)
+	assert.Equal(t, 20, first)
+	assert.Equal(t, 2, calls, "First should stop pulling after the first match")
+
+	collected := pipeline.Collect()
+	assert.Equal(t, []int{20, 40, 60}, collected.Unwrap())
+}
+
+func TestSeq_Stages(t *testing.T) {
+	s := NewSlice([]int{1, 2, 3, 4, 5})
+
+	tests := []struct {
+		name     string
+		seq      Seq[int]
+		expected []int
+	}{
+		{"Take", s.Iter().Take(2), []int{1, 2}},
+		{"TakeZero", s.Iter().Take(0), []int{}},
+		{"Skip", s.Iter().Skip(3), []int{4, 5}},
+		{"SkipAll", s.Iter().Skip(10), []int{}},
+		{"TakeWhile", s.Iter().TakeWhile(func(v int) bool { return v < 3 }), []int{1, 2}},
+		{"FlatMap", s.Iter().Take(2).FlatMap(func(v int) Seq[int] { return NewSlice([]int{v, v}).Iter() }), []int{1, 1, 2, 2}},
+		{"Distinct", SeqDistinct(NewSlice([]int{1, 1, 2, 1, 3}).Iter()), []int{1, 2, 3}},
+		{"SkipTake", s.Iter().Skip(1).Take(2), []int{2, 3}},
+	}
+
+	for _, tt := range tests {
+		collected := tt.seq.Collect()
+		assert.Equal(t, tt.expected, collected.Unwrap(), "%s should produce %v", tt.name, tt.expected)
+	}
+}
+
+func TestSeq_Terminals(t *testing.T) {
+	s := NewSlice([]int{1, 2, 3, 4})
+
+	assert.Equal(t, 10, s.Iter().Reduce(0, func(a, b int) int { return a + b }))
+	assert.Equal(t, "1234", SeqReduce(s.Iter(), "", func(acc string, v int) string { return acc + strconv.Itoa(v) }))
+	assert.Equal(t, 4, s.Iter().Count())
+	assert.True(t, s.Iter().Any(func(v int) bool { return v == 3 }))
+	assert.False(t, s.Iter().Any(func(v int) bool { return v == 5 }))
+	assert.True(t, s.Iter().All(func(v int) bool { return v > 0 }))
+	assert.False(t, s.Iter().All(func(v int) bool { return v > 1 }))
+
+	empty := NewSlice([]int{})
+	_, ok := empty.Iter().First()
+	assert.False(t, ok)
+}
+
+func TestSeq_TypeChanging(t *testing.T) {
+	s := NewSlice([]int{1, 2, 3})
+
+	strs := SeqMap(s.Iter(), strconv.Itoa).Collect()
+	assert.Equal(t, []string{"1", "2", "3"}, strs.Unwrap())
+
+	repeated := SeqFlatMap(s.Iter(), func(v int) Seq[string] {
+		return SeqMap(NewSlice(make([]int, v)).Iter(), func(int) string { return strconv.Itoa(v) })
+	}).Collect()
+	assert.Equal(t, []string{"1", "2", "2", "3", "3", "3"}, repeated.Unwrap())
+
+	chunks := SeqChunk(NewSlice([]int{1, 2, 3, 4, 5}).Iter(), 2).Collect()
+	assert.Equal(t, 3, chunks.Length())
+	last, _ := chunks.ValueAt(2)
+	assert.Equal(t, []int{5}, last.Unwrap())
+	assert.Equal(t, 0, SeqChunk(s.Iter(), 0).Count())
+}
+
+func TestSeq_ZipAndEnumerate(t *testing.T) {
+	names := NewSlice([]string{"a", "b", "c"})
+	ages := NewSlice([]int{1, 2})
+
+	zipped := CollectMap(SeqZip(names.Iter(), ages.Iter()))
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, zipped.Unwrap())
+
+	indexed := CollectMap(names.Iter().Enumerate())
+	assert.Equal(t, map[int]string{0: "a", 1: "b", 2: "c"}, indexed.Unwrap())
+
+	indexes := names.Iter().Enumerate().Take(2).Keys().Collect()
+	assert.Equal(t, []int{0, 1}, indexes.Unwrap())
+}
+
+func TestSeq_Map(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2, "c": 3})
+
+	filtered := CollectMap(m.Iter().Filter(func(k string, v int) bool { return v > 1 }))
+	assert.Equal(t, map[string]int{"b": 2, "c": 3}, filtered.Unwrap())
+
+	keys := m.KeysIter().Collect()
+	assert.ElementsMatch(t, []string{"a", "b", "c"}, keys.Unwrap())
+
+	values := m.ValuesIter().Collect()
+	assert.ElementsMatch(t, []int{1, 2, 3}, values.Unwrap())
+
+	assert.Equal(t, 2, m.Iter().Skip(1).Count())
+	assert.Equal(t, 1, m.Iter().Take(1).Count())
+
+	sum := 0
+	for _, v := range m.Iter() {
+		sum += v
+	}
+	assert.Equal(t, 6, sum)
+}
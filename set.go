@@ -0,0 +1,209 @@
+package wrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"slices"
+)
+
+// Set is a generic wrapper for a set of values of type T, backed by a map.
+// The zero value is an empty set ready to use.
+type Set[T comparable] struct {
+	X map[T]struct{}
+}
+
+// NewSet creates a new Set instance holding the provided values.
+func NewSet[T comparable](values ...T) Set[T] {
+	s := Set[T]{X: make(map[T]struct{}, len(values))}
+	s.Add(values...)
+	return s
+}
+
+// SetFromSlice creates a new Set instance holding the values of the provided Slice.
+func SetFromSlice[T comparable](s Slice[T]) Set[T] {
+	return NewSet(s.X...)
+}
+
+// SetFromMapKeys creates a new Set instance holding the keys of the provided Map.
+func SetFromMapKeys[K comparable, V any](m Map[K, V]) Set[K] {
+	s := Set[K]{X: make(map[K]struct{}, len(m.X))}
+	for key := range m.X {
+		s.X[key] = struct{}{}
+	}
+	return s
+}
+
+// Unwrap returns the underlying map of type map[T]struct{}.
+func (s *Set[T]) Unwrap() map[T]struct{} {
+	return s.X
+}
+
+// Add adds one or more values to the set.
+func (s *Set[T]) Add(values ...T) {
+	if s.X == nil {
+		s.X = make(map[T]struct{}, len(values))
+	}
+	for _, v := range values {
+		s.X[v] = struct{}{}
+	}
+}
+
+// Remove removes one or more values from the set.
+func (s *Set[T]) Remove(values ...T) {
+	for _, v := range values {
+		delete(s.X, v)
+	}
+}
+
+// Has checks if the specified value is in the set.
+func (s Set[T]) Has(value T) bool {
+	_, exists := s.X[value]
+	return exists
+}
+
+// Len returns the number of values in the set.
+func (s Set[T]) Len() int {
+	return len(s.X)
+}
+
+// IsEmpty returns true if the set is empty, otherwise false.
+func (s Set[T]) IsEmpty() bool {
+	return len(s.X) == 0
+}
+
+// Clear removes all values from the set.
+func (s *Set[T]) Clear() {
+	s.X = make(map[T]struct{})
+}
+
+// Clone returns a copy of the Set.
+func (s Set[T]) Clone() Set[T] {
+	return DeepCopy(s)
+}
+
+// Union returns a new Set holding the values that are in either set.
+func (s Set[T]) Union(other Set[T]) Set[T] {
+	union := Set[T]{X: make(map[T]struct{}, len(s.X)+len(other.X))}
+	for v := range s.X {
+		union.X[v] = struct{}{}
+	}
+	for v := range other.X {
+		union.X[v] = struct{}{}
+	}
+	return union
+}
+
+// Intersection returns a new Set holding the values that are in both sets.
+func (s Set[T]) Intersection(other Set[T]) Set[T] {
+	small, large := s, other
+	if len(small.X) > len(large.X) {
+		small, large = large, small
+	}
+	intersection := Set[T]{X: make(map[T]struct{})}
+	for v := range small.X {
+		if large.Has(v) {
+			intersection.X[v] = struct{}{}
+		}
+	}
+	return intersection
+}
+
+// Difference returns a new Set holding the values of s that are not in other.
+func (s Set[T]) Difference(other Set[T]) Set[T] {
+	difference := Set[T]{X: make(map[T]struct{})}
+	for v := range s.X {
+		if !other.Has(v) {
+			difference.X[v] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// SymmetricDifference returns a new Set holding the values that are in exactly one of the sets.
+func (s Set[T]) SymmetricDifference(other Set[T]) Set[T] {
+	difference := s.Difference(other)
+	for v := range other.X {
+		if !s.Has(v) {
+			difference.X[v] = struct{}{}
+		}
+	}
+	return difference
+}
+
+// IsSubset returns true if every value of s is in other.
+func (s Set[T]) IsSubset(other Set[T]) bool {
+	if len(s.X) > len(other.X) {
+		return false
+	}
+	for v := range s.X {
+		if !other.Has(v) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsSuperset returns true if every value of other is in s.
+func (s Set[T]) IsSuperset(other Set[T]) bool {
+	return other.IsSubset(s)
+}
+
+// Equal returns true if both sets hold the same values.
+func (s Set[T]) Equal(other Set[T]) bool {
+	return len(s.X) == len(other.X) && s.IsSubset(other)
+}
+
+// ToSlice returns a new Slice holding the values of the set, sorted when T is an ordered type.
+func (s Set[T]) ToSlice() Slice[T] {
+	values := make([]T, 0, len(s.X))
+	for v := range s.X {
+		values = append(values, v)
+	}
+	if isOrderedKind[T]() {
+		slices.SortFunc(values, orderedComparator[T]())
+	}
+	return NewSlice(values)
+}
+
+// Iter returns a lazy sequence over the values of the set, in no particular order.
+func (s Set[T]) Iter() Seq[T] {
+	return func(yield func(T) bool) {
+		for v := range s.X {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// UnmarshalJSON unmarshals a JSON array into the Set. Duplicate values are collapsed.
+func (s *Set[T]) UnmarshalJSON(data []byte) error {
+	var values []T
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+	s.Clear()
+	s.Add(values...)
+	return nil
+}
+
+// MarshalJSON marshals the Set into a JSON array, sorted when T is an ordered type.
+func (s Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.ToSlice().X)
+}
+
+// UnmarshalXML unmarshals XML data into the Set, using the same layout as Slice.
+func (s *Set[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	var values Slice[T]
+	if err := values.UnmarshalXML(d, start); err != nil {
+		return err
+	}
+	s.Clear()
+	s.Add(values.X...)
+	return nil
+}
+
+// MarshalXML marshals the Set into XML, using the same layout as Slice, sorted when T is an ordered type.
+func (s Set[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return s.ToSlice().MarshalXML(e, start)
+}
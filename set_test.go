@@ -0,0 +1,108 @@
+package wrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSet_AddRemoveHas(t *testing.T) {
+	var s Set[string]
+	assert.True(t, s.IsEmpty())
+
+	s.Add("a", "b", "a")
+	assert.Equal(t, 2, s.Len())
+	assert.True(t, s.Has("a"))
+	assert.False(t, s.Has("c"))
+
+	s.Remove("a", "missing")
+	assert.False(t, s.Has("a"))
+	assert.Equal(t, 1, s.Len())
+
+	s.Clear()
+	assert.True(t, s.IsEmpty())
+}
+
+func TestSet_Algebra(t *testing.T) {
+	a := NewSet(1, 2, 3)
+	b := NewSet(2, 3, 4)
+
+	tests := []struct {
+		name     string
+		result   Set[int]
+		expected []int
+	}{
+		{"Union", a.Union(b), []int{1, 2, 3, 4}},
+		{"Intersection", a.Intersection(b), []int{2, 3}},
+		{"Difference", a.Difference(b), []int{1}},
+		{"SymmetricDifference", a.SymmetricDifference(b), []int{1, 4}},
+		{"EmptyIntersection", a.Intersection(NewSet[int]()), []int{}},
+	}
+
+	for _, tt := range tests {
+		values := tt.result.ToSlice()
+		assert.Equal(t, tt.expected, values.Unwrap(), tt.name)
+	}
+
+	assert.Equal(t, 3, a.Len(), "Operations should not modify their operands")
+}
+
+func TestSet_Relations(t *testing.T) {
+	small := NewSet("a")
+	large := NewSet("a", "b")
+
+	assert.True(t, small.IsSubset(large))
+	assert.False(t, large.IsSubset(small))
+	assert.True(t, large.IsSuperset(small))
+	assert.True(t, NewSet[string]().IsSubset(small))
+	assert.True(t, large.Equal(NewSet("b", "a")))
+	assert.False(t, large.Equal(small))
+	assert.False(t, NewSet("a", "c").Equal(large))
+}
+
+func TestSet_Conversions(t *testing.T) {
+	fromSlice := SetFromSlice(NewSlice([]int{3, 1, 3, 2}))
+	values := fromSlice.ToSlice()
+	assert.Equal(t, []int{1, 2, 3}, values.Unwrap(), "ToSlice should be sorted for ordered types")
+
+	fromKeys := SetFromMapKeys(NewMap(map[string]bool{"x": true, "y": false}))
+	assert.True(t, fromKeys.Equal(NewSet("x", "y")))
+
+	assert.Equal(t, 3, fromSlice.Iter().Count())
+}
+
+func TestSet_JSON(t *testing.T) {
+	data, err := json.Marshal(NewSet("c", "a", "b"))
+	assert.NoError(t, err)
+	assert.Equal(t, `["a","b","c"]`, string(data))
+
+	var s Set[int]
+	err = json.Unmarshal([]byte(`[3,1,3]`), &s)
+	assert.NoError(t, err)
+	assert.True(t, s.Equal(NewSet(1, 3)))
+
+	type point struct{ X, Y int }
+	data, err = json.Marshal(NewSet(point{1, 2}))
+	assert.NoError(t, err)
+	assert.Equal(t, `[{"X":1,"Y":2}]`, string(data))
+
+	err = json.Unmarshal([]byte(`{}`), &s)
+	assert.Error(t, err)
+}
+
+func TestSet_XML(t *testing.T) {
+	type Test struct {
+		Tags Set[string] `xml:"tags"`
+	}
+
+	data, err := xml.Marshal(Test{Tags: NewSet("b", "a")})
+	assert.NoError(t, err)
+	assert.Equal(t, `<Test><tags><item>a</item><item>b</item></tags></Test>`, string(data))
+
+	var decoded Test
+	err = xml.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.True(t, decoded.Tags.Equal(NewSet("a", "b")))
+}
@@ -1,8 +1,13 @@
 package wrap
 
 import (
+	"database/sql/driver"
 	"encoding/json"
+	"encoding/xml"
+	"fmt"
 	"slices"
+
+	"gopkg.in/yaml.v3"
 )
 
 // Slice is a generic wrapper for a slice of type T.
@@ -163,6 +168,11 @@ func (s *Slice[T]) Copy() Slice[T] {
 	return NewSlice(copied)
 }
 
+// Clone returns a deep copy of the Slice, where nested slices, maps, pointers and wrappers are copied as well.
+func (s Slice[T]) Clone() Slice[T] {
+	return DeepCopy(s)
+}
+
 // Compact removes consecutive duplicate elements from the slice based on the provided comparison function.
 func (s *Slice[T]) Compact(compare func(a, b T) bool) Slice[T] {
 	return NewSlice(slices.CompactFunc(s.X, compare))
@@ -206,6 +216,17 @@ func (s *Slice[T]) Contains(equals func(T) bool) bool {
 	return exists
 }
 
+// Iter returns a lazy sequence over the values of the slice.
+func (s Slice[T]) Iter() Seq[T] {
+	return func(yield func(T) bool) {
+		for _, v := range s.X {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
 // UnmarshalJSON unmarshals JSON data into the Slice.
 func (s *Slice[T]) UnmarshalJSON(data []byte) error {
 	if err := json.Unmarshal(data, &s.X); err != nil {
@@ -218,3 +239,92 @@ func (s *Slice[T]) UnmarshalJSON(data []byte) error {
 func (s Slice[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(s.X)
 }
+
+// UnmarshalXML unmarshals XML data into the Slice. Every child element is decoded as an item, whatever its name.
+func (s *Slice[T]) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	s.X = []T{}
+
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			var value T
+			if err := d.DecodeElement(&value, &t); err != nil {
+				return err
+			}
+			s.X = append(s.X, value)
+
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXML marshals the Slice into XML, writing each value as an <item> child element. Use XMLSlice for other names.
+// If the slice is nil, no element is written.
+func (s Slice[T]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	return marshalXMLItems(e, start, s.X, "item")
+}
+
+// marshalXMLItems writes the values as children of start, each one in an element with the provided name.
+// If the slice is nil, no element is written.
+func marshalXMLItems[T any](e *xml.Encoder, start xml.StartElement, values []T, name string) error {
+	if values == nil {
+		return nil
+	}
+
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+
+	item := xml.StartElement{Name: xml.Name{Local: name}}
+	for _, v := range values {
+		if err := e.EncodeElement(v, item); err != nil {
+			return err
+		}
+	}
+
+	return e.EncodeToken(start.End())
+}
+
+// UnmarshalYAML unmarshals a YAML sequence into the Slice.
+func (s *Slice[T]) UnmarshalYAML(node *yaml.Node) error {
+	return node.Decode(&s.X)
+}
+
+// MarshalYAML marshals the Slice into a YAML sequence.
+func (s Slice[T]) MarshalYAML() (any, error) {
+	return s.X, nil
+}
+
+// Scan implements the sql.Scanner interface. It expects a JSON array stored as text or bytes; NULL sets the slice to nil.
+func (s *Slice[T]) Scan(src any) error {
+	if src == nil {
+		s.X = nil
+		return nil
+	}
+	return scanJSON(src, &s.X)
+}
+
+// Value implements the driver.Valuer interface. The slice is stored as JSON bytes, or NULL if it is nil.
+func (s Slice[T]) Value() (driver.Value, error) {
+	if s.X == nil {
+		return nil, nil
+	}
+	return json.Marshal(s.X)
+}
+
+// scanJSON unmarshals a JSON text or bytes database value into dst.
+func scanJSON(src any, dst any) error {
+	switch data := src.(type) {
+	case []byte:
+		return json.Unmarshal(data, dst)
+	case string:
+		return json.Unmarshal([]byte(data), dst)
+	}
+	return fmt.Errorf("wrap: cannot scan %T as JSON", src)
+}
@@ -1,8 +1,10 @@
 package wrap
 
 import (
+	"encoding/xml"
 	"testing"
 	"github.com/stretchr/testify/assert"
+	"gopkg.in/yaml.v3"
 )
 
 func TestSlice_NewSlice(t *testing.T) {
@@ -342,3 +344,65 @@ func TestSlice_RemoveAt(t *testing.T) {
 		assert.ElementsMatch(t, tt.expected, s.Unwrap(), "Slice after RemoveAt() should be %v", tt.expected)
 	}
 }
+
+func TestSlice_MarshalXML(t *testing.T) {
+	type Test struct {
+		XMLName xml.Name      `xml:"test"`
+		Tags    Slice[string] `xml:"tags"`
+	}
+
+	tests := []struct {
+		initial  []string
+		expected string
+	}{
+		{[]string{"a", "b"}, "<test><tags><item>a</item><item>b</item></tags></test>"},
+		{[]string{}, "<test><tags></tags></test>"},
+		{nil, "<test></test>"},
+	}
+
+	for _, tt := range tests {
+		data, err := xml.Marshal(Test{Tags: NewSlice(tt.initial)})
+		assert.NoError(t, err)
+		assert.Equal(t, tt.expected, string(data))
+	}
+}
+
+func TestSlice_UnmarshalXML(t *testing.T) {
+	type Point struct {
+		X int `xml:"x,attr"`
+		Y int `xml:"y,attr"`
+	}
+	type Test struct {
+		Numbers Slice[int]   `xml:"numbers"`
+		Points  Slice[Point] `xml:"points"`
+	}
+
+	var test Test
+	err := xml.Unmarshal([]byte(`<test><numbers><item>1</item><n>2</n></numbers><points><p x="1" y="2"/></points></test>`), &test)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, test.Numbers.Unwrap())
+	assert.Equal(t, []Point{{X: 1, Y: 2}}, test.Points.Unwrap())
+
+	err = xml.Unmarshal([]byte(`<test><numbers></numbers></test>`), &test)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{}, test.Numbers.Unwrap())
+}
+
+func TestSlice_YAML(t *testing.T) {
+	type Test struct {
+		Tags Slice[string] `yaml:"tags"`
+	}
+
+	data, err := yaml.Marshal(Test{Tags: NewSlice([]string{"a", "b"})})
+	assert.NoError(t, err)
+	assert.Equal(t, "tags:\n    - a\n    - b\n", string(data))
+
+	var decoded Test
+	err = yaml.Unmarshal(data, &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b"}, decoded.Tags.Unwrap())
+
+	err = yaml.Unmarshal([]byte("tags: [c]"), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"c"}, decoded.Tags.Unwrap())
+}
@@ -0,0 +1,177 @@
+package wrap
+
+import (
+	"slices"
+)
+
+// SliceTx is a transaction applying a batch of changes to a Slice, which can be committed or rolled back as a whole.
+// Changes are visible in the Slice as soon as they are made. A SliceTx is not safe for concurrent use, and its
+// mutation methods return ErrTxDone once it is committed or rolled back.
+type SliceTx[T any] struct {
+	tx
+	s *Slice[T]
+}
+
+// Begin starts a transaction on the Slice. If a History is provided, the transaction is recorded into it on commit.
+func (s *Slice[T]) Begin(history ...*History) *SliceTx[T] {
+	return &SliceTx[T]{tx: newTx(history), s: s}
+}
+
+// ValueAt retrieves the value at the specified index and a boolean indicating success.
+func (t *SliceTx[T]) ValueAt(index int) (T, bool) {
+	return t.s.ValueAt(index)
+}
+
+// Length returns the number of elements in the slice.
+func (t *SliceTx[T]) Length() int {
+	return t.s.Length()
+}
+
+// SetValueAt sets the value at the specified index and returns whether the operation was successful.
+func (t *SliceTx[T]) SetValueAt(index int, value T) (bool, error) {
+	if err := t.check(); err != nil {
+		return false, err
+	}
+	old, ok := t.s.ValueAt(index)
+	if !ok {
+		return false, nil
+	}
+	t.s.SetValueAt(index, value)
+	t.record(func() {
+		t.s.SetValueAt(index, old)
+	}, func() {
+		t.s.SetValueAt(index, value)
+	})
+	return true, nil
+}
+
+// Append adds one or more values to the end of the slice.
+func (t *SliceTx[T]) Append(values ...T) error {
+	_, err := t.InsertAt(len(t.s.X), values...)
+	return err
+}
+
+// Prepend adds one or more values to the beginning of the slice.
+func (t *SliceTx[T]) Prepend(values ...T) error {
+	_, err := t.InsertAt(0, values...)
+	return err
+}
+
+// InsertAt inserts one or more values at the specified index and returns whether the operation was successful.
+func (t *SliceTx[T]) InsertAt(index int, values ...T) (bool, error) {
+	if err := t.check(); err != nil {
+		return false, err
+	}
+	values = slices.Clone(values)
+	if !t.s.InsertAt(index, values...) {
+		return false, nil
+	}
+	if len(values) == 0 {
+		return true, nil
+	}
+	t.record(func() {
+		t.s.RemoveAt(index, len(values))
+	}, func() {
+		t.s.InsertAt(index, slices.Clone(values)...)
+	})
+	return true, nil
+}
+
+// Pop removes and returns the last value from the slice, or false if the slice is empty.
+func (t *SliceTx[T]) Pop() (T, bool, error) {
+	removed, err := t.RemoveAt(len(t.s.X) - 1)
+	value, ok := removed.ValueAt(0)
+	return value, ok, err
+}
+
+// Shift removes and returns the first value from the slice, or false if the slice is empty.
+func (t *SliceTx[T]) Shift() (T, bool, error) {
+	removed, err := t.RemoveAt(0)
+	value, ok := removed.ValueAt(0)
+	return value, ok, err
+}
+
+// RemoveAt removes a specified number of elements starting from the index and returns the removed elements as a new Slice.
+func (t *SliceTx[T]) RemoveAt(index int, count ...int) (Slice[T], error) {
+	if err := t.check(); err != nil {
+		return NewSlice([]T{}), err
+	}
+	removed := t.s.RemoveAt(index, count...)
+	if len(removed.X) == 0 {
+		return removed, nil
+	}
+	values := slices.Clone(removed.X)
+	t.record(func() {
+		t.s.InsertAt(index, slices.Clone(values)...)
+	}, func() {
+		t.s.RemoveAt(index, len(values))
+	})
+	return removed, nil
+}
+
+// Remove deletes all elements that satisfy the provided comparison function and returns removed elements as a new Slice.
+// The comparison function is called once per element.
+func (t *SliceTx[T]) Remove(compare func(T) bool) (Slice[T], error) {
+	if err := t.check(); err != nil {
+		return NewSlice([]T{}), err
+	}
+	var indexes []int
+	removed := NewSlice([]T{})
+	kept := t.s.X[:0]
+	for i, value := range t.s.X {
+		if !compare(value) {
+			kept = append(kept, value)
+			continue
+		}
+		indexes = append(indexes, i)
+		removed.Append(value)
+	}
+	clear(t.s.X[len(kept):])
+	t.s.X = kept
+	if len(indexes) == 0 {
+		return removed, nil
+	}
+
+	values := slices.Clone(removed.X)
+	t.record(func() {
+		// Inserting in ascending order puts every value back at its original index.
+		for i, index := range indexes {
+			t.s.InsertAt(index, values[i])
+		}
+	}, func() {
+		for i := len(indexes) - 1; i >= 0; i-- {
+			t.s.RemoveAt(indexes[i])
+		}
+	})
+	return removed, nil
+}
+
+// Crop reduces the slice to contain only elements up to the specified index.
+func (t *SliceTx[T]) Crop(index int) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	if index < 0 || index >= len(t.s.X) {
+		return nil
+	}
+	_, err := t.RemoveAt(index, len(t.s.X)-index)
+	return err
+}
+
+// Clear removes all elements from the slice.
+func (t *SliceTx[T]) Clear() error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	if len(t.s.X) == 0 {
+		return nil
+	}
+	old := t.s.X
+	t.s.Clear()
+	t.record(func() {
+		t.s.X = old
+	}, func() {
+		t.s.Clear()
+	})
+	return nil
+}
@@ -0,0 +1,120 @@
+package wrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSliceTx_Rollback(t *testing.T) {
+	s := NewSlice([]int{1, 2, 3, 4})
+
+	tx := s.Begin()
+	tx.Append(5, 6)
+	tx.Prepend(0)
+	ok, err := tx.InsertAt(2, 10, 11)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	ok, err = tx.SetValueAt(0, -1)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	removed, err := tx.RemoveAt(1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 10, 11}, removed.X)
+	value, ok, err := tx.Pop()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 6, value)
+	value, ok, err = tx.Shift()
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, -1, value)
+	ok, _ = tx.InsertAt(100, 1)
+	assert.False(t, ok)
+	ok, _ = tx.SetValueAt(100, 1)
+	assert.False(t, ok)
+	assert.Equal(t, []int{2, 3, 4, 5}, s.X)
+	assert.Equal(t, 4, tx.Length())
+
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, []int{1, 2, 3, 4}, s.X)
+}
+
+func TestSliceTx_Clear(t *testing.T) {
+	s := NewSlice([]int{1, 2})
+
+	tx := s.Begin()
+	tx.Clear()
+	tx.Append(3)
+	assert.Equal(t, []int{3}, s.X)
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, []int{1, 2}, s.X)
+}
+
+func TestSliceTx_RemoveAndCrop(t *testing.T) {
+	s := NewSlice([]int{1, 2, 3, 4, 5, 6})
+	history := NewHistory(0)
+
+	tx := s.Begin(history)
+	calls := 0
+	removed, err := tx.Remove(func(v int) bool {
+		calls++
+		return v%2 == 0
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, 6, calls, "The predicate runs once per element")
+	assert.Equal(t, []int{2, 4, 6}, removed.X)
+	assert.Equal(t, []int{1, 3, 5}, s.X)
+
+	assert.NoError(t, tx.Crop(1))
+	assert.NoError(t, tx.Crop(10), "Out of bounds, no change")
+	assert.Equal(t, []int{1}, s.X)
+
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, s.X)
+
+	tx = s.Begin(history)
+	tx.Remove(func(v int) bool { return v > 4 || v == 1 })
+	tx.Crop(2)
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []int{2, 3}, s.X)
+
+	history.Undo()
+	assert.Equal(t, []int{1, 2, 3, 4, 5, 6}, s.X)
+	history.Redo()
+	assert.Equal(t, []int{2, 3}, s.X)
+}
+
+func TestSliceTx_History(t *testing.T) {
+	s := NewSlice([]string{"a"})
+	history := NewHistory(0)
+
+	tx := s.Begin(history)
+	tx.Append("b", "c")
+	tx.RemoveAt(0)
+	assert.NoError(t, tx.Commit())
+
+	tx = s.Begin(history)
+	tx.SetValueAt(0, "x")
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, []string{"x", "c"}, s.X)
+
+	history.Undo()
+	history.Undo()
+	assert.Equal(t, []string{"a"}, s.X)
+	history.Redo()
+	history.Redo()
+	assert.Equal(t, []string{"x", "c"}, s.X)
+}
+
+func TestSliceTx_DoesNotAliasValues(t *testing.T) {
+	s := NewSlice([]int{})
+	values := []int{1, 2}
+
+	tx := s.Begin()
+	tx.Append(values...)
+	values[0] = 100
+	assert.Equal(t, []int{1, 2}, s.X)
+	assert.NoError(t, tx.Rollback())
+	assert.Empty(t, s.X)
+}
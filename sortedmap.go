@@ -0,0 +1,480 @@
+package wrap
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+)
+
+// SortedMap is a generic map with keys of type K and values of type V kept sorted by key.
+// It is backed by an AVL tree, so Get, Set and Delete run in O(log n), as do the order queries
+// (Floor, Ceiling, Lower, Higher, Rank and At). Keys, Values, Entries, iteration and the JSON encoding follow key order.
+// The zero value is an empty map ready to use for keys of an ordered kind; other keys need NewSortedMapFunc,
+// and setting a key on a zero value SortedMap of such keys panics.
+type SortedMap[K comparable, V any] struct {
+	root    *sortedNode[K, V]
+	compare func(a, b K) int
+}
+
+// sortedNode is a node of the AVL tree backing a SortedMap. size is the number of nodes in its subtree.
+type sortedNode[K comparable, V any] struct {
+	entry  MapEntry[K, V]
+	left   *sortedNode[K, V]
+	right  *sortedNode[K, V]
+	height int
+	size   int
+}
+
+// NewSortedMap creates a new empty SortedMap instance ordered by the natural order of K.
+func NewSortedMap[K cmp.Ordered, V any]() *SortedMap[K, V] {
+	return &SortedMap[K, V]{compare: cmp.Compare[K]}
+}
+
+// NewSortedMapFunc creates a new empty SortedMap instance ordered by the provided comparison function,
+// which returns a negative number when a < b, a positive number when a > b and zero when a == b.
+func NewSortedMapFunc[K comparable, V any](compare func(a, b K) int) *SortedMap[K, V] {
+	return &SortedMap[K, V]{compare: compare}
+}
+
+// SortedMapFromMap creates a new SortedMap instance holding the key-value pairs of the provided Map.
+func SortedMapFromMap[K cmp.Ordered, V any](m Map[K, V]) *SortedMap[K, V] {
+	sorted := NewSortedMap[K, V]()
+	for key, value := range m.X {
+		sorted.Set(key, value)
+	}
+	return sorted
+}
+
+// SortedMapFromEntries creates a new SortedMap instance holding the provided entries. Later entries overwrite earlier ones with the same key.
+func SortedMapFromEntries[K cmp.Ordered, V any](entries Slice[MapEntry[K, V]]) *SortedMap[K, V] {
+	sorted := NewSortedMap[K, V]()
+	for _, entry := range entries.X {
+		sorted.Set(entry.Key, entry.Value)
+	}
+	return sorted
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (m *SortedMap[K, V]) Get(key K) (V, bool) {
+	var zero V
+	node := m.find(key)
+	if node == nil {
+		return zero, false
+	}
+	return node.entry.Value, true
+}
+
+// Set adds or updates the value for the specified key and returns the SortedMap instance.
+func (m *SortedMap[K, V]) Set(key K, value V) *SortedMap[K, V] {
+	if m.compare == nil {
+		m.compare = orderedComparator[K]()
+	}
+	m.root = m.insert(m.root, key, value)
+	return m
+}
+
+// Delete removes the key-value pair associated with the specified key and returns the SortedMap instance.
+func (m *SortedMap[K, V]) Delete(key K) *SortedMap[K, V] {
+	m.root = m.remove(m.root, key)
+	return m
+}
+
+// Contains checks if the specified key exists in the map.
+func (m *SortedMap[K, V]) Contains(key K) bool {
+	return m.find(key) != nil
+}
+
+// Len returns the number of key-value pairs in the map.
+func (m *SortedMap[K, V]) Len() int {
+	return sortedSize(m.root)
+}
+
+// IsEmpty returns true if the map is empty, otherwise false.
+func (m *SortedMap[K, V]) IsEmpty() bool {
+	return m.root == nil
+}
+
+// Clear removes all key-value pairs from the map.
+func (m *SortedMap[K, V]) Clear() {
+	m.root = nil
+}
+
+// Keys returns a slice of all keys in the map, in key order.
+func (m *SortedMap[K, V]) Keys() []K {
+	keys := make([]K, 0, m.Len())
+	for key := range m.Iter() {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// Values returns a slice of all values in the map, in key order.
+func (m *SortedMap[K, V]) Values() Slice[V] {
+	values := make([]V, 0, m.Len())
+	for _, value := range m.Iter() {
+		values = append(values, value)
+	}
+	return NewSlice(values)
+}
+
+// Entries returns a slice of all key-value pairs in the map, in key order.
+func (m *SortedMap[K, V]) Entries() []MapEntry[K, V] {
+	entries := make([]MapEntry[K, V], 0, m.Len())
+	for key, value := range m.Iter() {
+		entries = append(entries, MapEntry[K, V]{Key: key, Value: value})
+	}
+	return entries
+}
+
+// Min returns the key-value pair with the smallest key, or false if the map is empty.
+func (m *SortedMap[K, V]) Min() (MapEntry[K, V], bool) {
+	return m.At(0)
+}
+
+// Max returns the key-value pair with the largest key, or false if the map is empty.
+func (m *SortedMap[K, V]) Max() (MapEntry[K, V], bool) {
+	return m.At(m.Len() - 1)
+}
+
+// Floor returns the key-value pair with the largest key less than or equal to the specified key, or false if there is none.
+func (m *SortedMap[K, V]) Floor(key K) (MapEntry[K, V], bool) {
+	return m.search(key, true, true)
+}
+
+// Ceiling returns the key-value pair with the smallest key greater than or equal to the specified key, or false if there is none.
+func (m *SortedMap[K, V]) Ceiling(key K) (MapEntry[K, V], bool) {
+	return m.search(key, false, true)
+}
+
+// Lower returns the key-value pair with the largest key strictly less than the specified key, or false if there is none.
+func (m *SortedMap[K, V]) Lower(key K) (MapEntry[K, V], bool) {
+	return m.search(key, true, false)
+}
+
+// Higher returns the key-value pair with the smallest key strictly greater than the specified key, or false if there is none.
+func (m *SortedMap[K, V]) Higher(key K) (MapEntry[K, V], bool) {
+	return m.search(key, false, false)
+}
+
+// Rank returns the number of keys in the map strictly less than the specified key.
+// When the key exists, this is its zero-based index in key order.
+func (m *SortedMap[K, V]) Rank(key K) int {
+	rank := 0
+	node := m.root
+	for node != nil {
+		if m.compare(key, node.entry.Key) <= 0 {
+			node = node.left
+		} else {
+			rank += sortedSize(node.left) + 1
+			node = node.right
+		}
+	}
+	return rank
+}
+
+// At returns the key-value pair at the specified zero-based index in key order, or false if the index is out of bounds.
+func (m *SortedMap[K, V]) At(index int) (MapEntry[K, V], bool) {
+	if index < 0 || index >= m.Len() {
+		return MapEntry[K, V]{}, false
+	}
+	node := m.root
+	for {
+		leftSize := sortedSize(node.left)
+		switch {
+		case index < leftSize:
+			node = node.left
+		case index > leftSize:
+			index -= leftSize + 1
+			node = node.right
+		default:
+			return node.entry, true
+		}
+	}
+}
+
+// Iter returns a lazy sequence over the key-value pairs of the map, in key order.
+func (m *SortedMap[K, V]) Iter() Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.walk(m.root, nil, nil, yield)
+	}
+}
+
+// Range returns a lazy sequence over the key-value pairs with keys in the half-open interval [from, to), in key order.
+func (m *SortedMap[K, V]) Range(from, to K) Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		m.walk(m.root, &from, &to, yield)
+	}
+}
+
+// ToMap returns a new Map holding the key-value pairs of the map.
+func (m *SortedMap[K, V]) ToMap() Map[K, V] {
+	copied := make(map[K]V, m.Len())
+	for key, value := range m.Iter() {
+		copied[key] = value
+	}
+	return NewMap(copied)
+}
+
+// ToSlice returns a new Slice holding the key-value pairs of the map, in key order.
+func (m *SortedMap[K, V]) ToSlice() Slice[MapEntry[K, V]] {
+	return NewSlice(m.Entries())
+}
+
+// UnmarshalJSON unmarshals a JSON object into the SortedMap.
+func (m *SortedMap[K, V]) UnmarshalJSON(data []byte) error {
+	m.Clear()
+	return decodeJSONObject(data, func(key K, value V) {
+		m.Set(key, value)
+	})
+}
+
+// MarshalJSON marshals the SortedMap into JSON. It produces a JSON object with keys in key order.
+func (m SortedMap[K, V]) MarshalJSON() ([]byte, error) {
+	return encodeJSONObject(m.Entries())
+}
+
+// find returns the node holding the key, or nil.
+func (m *SortedMap[K, V]) find(key K) *sortedNode[K, V] {
+	node := m.root
+	for node != nil {
+		c := m.compare(key, node.entry.Key)
+		switch {
+		case c < 0:
+			node = node.left
+		case c > 0:
+			node = node.right
+		default:
+			return node
+		}
+	}
+	return nil
+}
+
+// search returns the closest entry below (or above, if below is false) the key, including the key itself if inclusive is true.
+func (m *SortedMap[K, V]) search(key K, below, inclusive bool) (MapEntry[K, V], bool) {
+	var found *sortedNode[K, V]
+	node := m.root
+	for node != nil {
+		c := m.compare(key, node.entry.Key)
+		if c == 0 && inclusive {
+			return node.entry, true
+		}
+		if below {
+			if c > 0 {
+				found = node
+				node = node.right
+			} else {
+				node = node.left
+			}
+		} else {
+			if c < 0 {
+				found = node
+				node = node.left
+			} else {
+				node = node.right
+			}
+		}
+	}
+	if found == nil {
+		return MapEntry[K, V]{}, false
+	}
+	return found.entry, true
+}
+
+// walk yields the entries of the subtree in order, skipping keys outside [from, to) when bounds are given.
+// It returns false once yield has asked to stop.
+func (m *SortedMap[K, V]) walk(node *sortedNode[K, V], from, to *K, yield func(K, V) bool) bool {
+	if node == nil {
+		return true
+	}
+	aboveFrom := from == nil || m.compare(node.entry.Key, *from) >= 0
+	belowTo := to == nil || m.compare(node.entry.Key, *to) < 0
+
+	if aboveFrom && !m.walk(node.left, from, to, yield) {
+		return false
+	}
+	if aboveFrom && belowTo && !yield(node.entry.Key, node.entry.Value) {
+		return false
+	}
+	if belowTo {
+		return m.walk(node.right, from, to, yield)
+	}
+	return true
+}
+
+// insert adds or updates the key in the subtree and returns its new root.
+func (m *SortedMap[K, V]) insert(node *sortedNode[K, V], key K, value V) *sortedNode[K, V] {
+	if node == nil {
+		return &sortedNode[K, V]{entry: MapEntry[K, V]{Key: key, Value: value}, height: 1, size: 1}
+	}
+	c := m.compare(key, node.entry.Key)
+	switch {
+	case c < 0:
+		node.left = m.insert(node.left, key, value)
+	case c > 0:
+		node.right = m.insert(node.right, key, value)
+	default:
+		node.entry.Value = value
+		return node
+	}
+	return sortedBalance(node)
+}
+
+// remove deletes the key from the subtree and returns its new root.
+func (m *SortedMap[K, V]) remove(node *sortedNode[K, V], key K) *sortedNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	c := m.compare(key, node.entry.Key)
+	switch {
+	case c < 0:
+		node.left = m.remove(node.left, key)
+	case c > 0:
+		node.right = m.remove(node.right, key)
+	default:
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+		var successor *sortedNode[K, V]
+		node.right, successor = sortedRemoveMin(node.right)
+		successor.left = node.left
+		successor.right = node.right
+		node = successor
+	}
+	return sortedBalance(node)
+}
+
+// sortedRemoveMin detaches the smallest node of the subtree, returning the new root and the detached node.
+func sortedRemoveMin[K comparable, V any](node *sortedNode[K, V]) (*sortedNode[K, V], *sortedNode[K, V]) {
+	if node.left == nil {
+		return node.right, node
+	}
+	var min *sortedNode[K, V]
+	node.left, min = sortedRemoveMin(node.left)
+	return sortedBalance(node), min
+}
+
+// sortedBalance restores the AVL invariant at node, whose subtrees are balanced, and returns the subtree root.
+func sortedBalance[K comparable, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	sortedUpdate(node)
+	switch factor := sortedHeight(node.left) - sortedHeight(node.right); {
+	case factor > 1:
+		if sortedHeight(node.left.left) < sortedHeight(node.left.right) {
+			node.left = sortedRotateLeft(node.left)
+		}
+		return sortedRotateRight(node)
+	case factor < -1:
+		if sortedHeight(node.right.right) < sortedHeight(node.right.left) {
+			node.right = sortedRotateRight(node.right)
+		}
+		return sortedRotateLeft(node)
+	}
+	return node
+}
+
+func sortedRotateLeft[K comparable, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	pivot := node.right
+	node.right = pivot.left
+	pivot.left = node
+	sortedUpdate(node)
+	sortedUpdate(pivot)
+	return pivot
+}
+
+func sortedRotateRight[K comparable, V any](node *sortedNode[K, V]) *sortedNode[K, V] {
+	pivot := node.left
+	node.left = pivot.right
+	pivot.right = node
+	sortedUpdate(node)
+	sortedUpdate(pivot)
+	return pivot
+}
+
+func sortedUpdate[K comparable, V any](node *sortedNode[K, V]) {
+	node.height = 1 + max(sortedHeight(node.left), sortedHeight(node.right))
+	node.size = 1 + sortedSize(node.left) + sortedSize(node.right)
+}
+
+func sortedHeight[K comparable, V any](node *sortedNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func sortedSize[K comparable, V any](node *sortedNode[K, V]) int {
+	if node == nil {
+		return 0
+	}
+	return node.size
+}
+
+// compareOrdered compares two values of an ordered kind (integers, floats and strings) by their natural order.
+// It panics for other kinds.
+func compareOrdered[T any](a, b T) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	case reflect.String:
+		return cmp.Compare(va.String(), vb.String())
+	}
+	panic(fmt.Sprintf("wrap: %T is not an ordered type, a comparison function is required", a))
+}
+
+// orderedComparator returns the natural order comparison function of T, checking once that T is of an ordered kind.
+// It panics for other kinds.
+func orderedComparator[T any]() func(a, b T) int {
+	var zero T
+	switch any(zero).(type) {
+	case int:
+		return any(cmp.Compare[int]).(func(a, b T) int)
+	case int64:
+		return any(cmp.Compare[int64]).(func(a, b T) int)
+	case uint64:
+		return any(cmp.Compare[uint64]).(func(a, b T) int)
+	case float64:
+		return any(cmp.Compare[float64]).(func(a, b T) int)
+	case string:
+		return any(cmp.Compare[string]).(func(a, b T) int)
+	}
+	if !isOrderedKind[T]() {
+		panic(fmt.Sprintf("wrap: %s is not an ordered type, create the SortedMap with NewSortedMapFunc", reflect.TypeFor[T]()))
+	}
+	return compareOrdered[T]
+}
+
+// isOrderedKind reports whether values of type T can be compared with compareOrdered.
+func isOrderedKind[T any]() bool {
+	switch reflect.TypeFor[T]().Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// cloneReflect returns a deep copy of the SortedMap for DeepCopy, with the same tree shape and comparison function.
+func (m *SortedMap[K, V]) cloneReflect(c *cloneState) reflect.Value {
+	return reflect.ValueOf(SortedMap[K, V]{root: sortedClone(c, m.root), compare: m.compare})
+}
+
+// sortedClone returns a deep copy of the subtree rooted at node.
+func sortedClone[K comparable, V any](c *cloneState, node *sortedNode[K, V]) *sortedNode[K, V] {
+	if node == nil {
+		return nil
+	}
+	copied := *node
+	copied.entry.Value = cloneValue(c, node.entry.Value)
+	copied.left = sortedClone(c, node.left)
+	copied.right = sortedClone(c, node.right)
+	return &copied
+}
@@ -0,0 +1,217 @@
+package wrap
+
+import (
+	"encoding/json"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSortedMap_SetGetDelete(t *testing.T) {
+	var m SortedMap[int, string]
+	m.Set(5, "e").Set(1, "a").Set(3, "c").Set(9, "i")
+	m.Set(3, "C")
+
+	assert.Equal(t, 4, m.Len())
+	assert.Equal(t, []int{1, 3, 5, 9}, m.Keys())
+	values := m.Values()
+	assert.Equal(t, []string{"a", "C", "e", "i"}, values.Unwrap())
+
+	value, ok := m.Get(3)
+	assert.True(t, ok)
+	assert.Equal(t, "C", value)
+
+	m.Delete(3).Delete(100)
+	assert.False(t, m.Contains(3))
+	assert.Equal(t, []int{1, 5, 9}, m.Keys())
+
+	m.Clear()
+	assert.True(t, m.IsEmpty())
+	_, ok = m.Min()
+	assert.False(t, ok)
+}
+
+func TestSortedMap_Navigation(t *testing.T) {
+	m := NewSortedMap[int, string]()
+	for _, k := range []int{10, 20, 30, 40} {
+		m.Set(k, "")
+	}
+
+	tests := []struct {
+		name     string
+		query    func(int) (MapEntry[int, string], bool)
+		key      int
+		expected int
+		ok       bool
+	}{
+		{"Floor exact", m.Floor, 20, 20, true},
+		{"Floor between", m.Floor, 25, 20, true},
+		{"Floor below", m.Floor, 5, 0, false},
+		{"Ceiling exact", m.Ceiling, 20, 20, true},
+		{"Ceiling between", m.Ceiling, 25, 30, true},
+		{"Ceiling above", m.Ceiling, 45, 0, false},
+		{"Lower exact", m.Lower, 20, 10, true},
+		{"Lower min", m.Lower, 10, 0, false},
+		{"Higher exact", m.Higher, 20, 30, true},
+		{"Higher max", m.Higher, 40, 0, false},
+	}
+
+	for _, tt := range tests {
+		entry, ok := tt.query(tt.key)
+		assert.Equal(t, tt.ok, ok, tt.name)
+		assert.Equal(t, tt.expected, entry.Key, tt.name)
+	}
+
+	min, _ := m.Min()
+	max, _ := m.Max()
+	assert.Equal(t, 10, min.Key)
+	assert.Equal(t, 40, max.Key)
+}
+
+func TestSortedMap_RankAndAt(t *testing.T) {
+	m := NewSortedMap[string, int]()
+	for i, k := range []string{"d", "b", "a", "c"} {
+		m.Set(k, i)
+	}
+
+	assert.Equal(t, 0, m.Rank("a"))
+	assert.Equal(t, 2, m.Rank("c"))
+	assert.Equal(t, 2, m.Rank("bb"))
+	assert.Equal(t, 4, m.Rank("z"))
+
+	entry, ok := m.At(1)
+	assert.True(t, ok)
+	assert.Equal(t, "b", entry.Key)
+	_, ok = m.At(4)
+	assert.False(t, ok)
+	_, ok = m.At(-1)
+	assert.False(t, ok)
+}
+
+func TestSortedMap_Range(t *testing.T) {
+	m := NewSortedMap[int, int]()
+	for k := 0; k < 10; k++ {
+		m.Set(k, k*k)
+	}
+
+	keys := m.Range(3, 7).Keys().Collect()
+	assert.Equal(t, []int{3, 4, 5, 6}, keys.Unwrap())
+
+	empty := m.Range(7, 3).Keys().Collect()
+	assert.Equal(t, []int{}, empty.Unwrap())
+
+	first := m.Range(2, 100).Take(2).Values().Collect()
+	assert.Equal(t, []int{4, 9}, first.Unwrap())
+}
+
+func TestSortedMap_Comparator(t *testing.T) {
+	m := NewSortedMapFunc[string, int](func(a, b string) int {
+		return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+	})
+	m.Set("b", 1).Set("A", 2).Set("c", 3).Set("B", 4)
+
+	assert.Equal(t, []string{"A", "b", "c"}, m.Keys())
+	value, _ := m.Get("b")
+	assert.Equal(t, 4, value, "Keys equal under the comparator should be updated")
+
+	type point struct{ x, y int }
+	assert.PanicsWithValue(t, "wrap: wrap.point is not an ordered type, create the SortedMap with NewSortedMapFunc", func() {
+		var points SortedMap[point, int]
+		points.Set(point{1, 2}, 1)
+	}, "Non-ordered keys need a comparator")
+
+	type id int16
+	var ids SortedMap[id, string]
+	ids.Set(3, "c").Set(-1, "a")
+	assert.Equal(t, []id{-1, 3}, ids.Keys(), "Named ordered kinds use their natural order")
+}
+
+func TestSortedMap_RandomizedAgainstMap(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	m := NewSortedMap[int, int]()
+	reference := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		key := rng.Intn(500)
+		if rng.Intn(3) == 0 {
+			m.Delete(key)
+			delete(reference, key)
+		} else {
+			m.Set(key, i)
+			reference[key] = i
+		}
+	}
+
+	keys := make([]int, 0, len(reference))
+	for key := range reference {
+		keys = append(keys, key)
+	}
+	sort.Ints(keys)
+
+	assert.Equal(t, len(reference), m.Len())
+	assert.Equal(t, keys, m.Keys())
+	for i, key := range keys {
+		assert.Equal(t, i, m.Rank(key))
+		entry, _ := m.At(i)
+		assert.Equal(t, reference[key], entry.Value)
+	}
+	assertSortedBalanced(t, m.root)
+}
+
+func assertSortedBalanced[K comparable, V any](t *testing.T, node *sortedNode[K, V]) {
+	if node == nil {
+		return
+	}
+	diff := sortedHeight(node.left) - sortedHeight(node.right)
+	assert.True(t, diff >= -1 && diff <= 1, "AVL invariant should hold")
+	assert.Equal(t, 1+sortedSize(node.left)+sortedSize(node.right), node.size)
+	assertSortedBalanced(t, node.left)
+	assertSortedBalanced(t, node.right)
+}
+
+func TestSortedMap_JSON(t *testing.T) {
+	var m SortedMap[string, int]
+	err := json.Unmarshal([]byte(`{"b":2,"c":3,"a":1}`), &m)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a", "b", "c"}, m.Keys())
+
+	data, err := json.Marshal(&m)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1,"b":2,"c":3}`, string(data))
+
+	numbers := NewSortedMap[int, bool]()
+	numbers.Set(10, true).Set(2, false)
+	data, err = json.Marshal(numbers)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"2":false,"10":true}`, string(data), "Keys should be in numeric order")
+
+	data, err = json.Marshal(NewSortedMap[int, bool]())
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(data))
+
+	type Test struct {
+		Limits SortedMap[string, int] `json:"limits"`
+	}
+	var test Test
+	test.Limits.Set("b", 2).Set("a", 1)
+	data, err = json.Marshal(test)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"limits":{"a":1,"b":2}}`, string(data), "By-value fields should keep their data")
+}
+
+func TestSortedMap_Conversions(t *testing.T) {
+	m := SortedMapFromMap(NewMap(map[string]int{"b": 2, "a": 1}))
+	assert.Equal(t, []string{"a", "b"}, m.Keys())
+
+	plain := m.ToMap()
+	assert.Equal(t, map[string]int{"a": 1, "b": 2}, plain.Unwrap())
+
+	entries := m.ToSlice()
+	assert.Equal(t, []MapEntry[string, int]{{"a", 1}, {"b", 2}}, entries.Unwrap())
+
+	fromEntries := SortedMapFromEntries(NewSlice([]MapEntry[string, int]{{"z", 1}, {"y", 2}, {"z", 3}}))
+	assert.Equal(t, []MapEntry[string, int]{{"y", 2}, {"z", 3}}, fromEntries.Entries())
+}
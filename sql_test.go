@@ -0,0 +1,215 @@
+package wrap_test
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/twoojoo/wrap"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeDriver is a minimal in-memory database/sql driver. Each DSN names a single table:
+// "INSERT" statements append their arguments as a row and "SELECT" statements return all rows.
+type fakeDriver struct {
+	mu     sync.Mutex
+	tables map[string][][]driver.Value
+}
+
+type fakeConn struct {
+	driver *fakeDriver
+	table  string
+}
+
+type fakeStmt struct {
+	conn  *fakeConn
+	query string
+}
+
+type fakeRows struct {
+	rows [][]driver.Value
+	next int
+}
+
+var fake = &fakeDriver{tables: map[string][][]driver.Value{}}
+
+func init() {
+	sql.Register("wrapfake", fake)
+}
+
+func (d *fakeDriver) Open(name string) (driver.Conn, error) {
+	return &fakeConn{driver: d, table: name}, nil
+}
+
+func (c *fakeConn) Prepare(query string) (driver.Stmt, error) {
+	return &fakeStmt{conn: c, query: query}, nil
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) Begin() (driver.Tx, error) {
+	return nil, errors.New("fake driver: transactions are not supported")
+}
+
+func (s *fakeStmt) Close() error {
+	return nil
+}
+
+func (s *fakeStmt) NumInput() int {
+	return -1
+}
+
+func (s *fakeStmt) Exec(args []driver.Value) (driver.Result, error) {
+	if !strings.HasPrefix(s.query, "INSERT") {
+		return nil, errors.New("fake driver: unsupported statement")
+	}
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	s.conn.driver.tables[s.conn.table] = append(s.conn.driver.tables[s.conn.table], args)
+	return driver.RowsAffected(1), nil
+}
+
+func (s *fakeStmt) Query(args []driver.Value) (driver.Rows, error) {
+	if !strings.HasPrefix(s.query, "SELECT") {
+		return nil, errors.New("fake driver: unsupported statement")
+	}
+	s.conn.driver.mu.Lock()
+	defer s.conn.driver.mu.Unlock()
+	return &fakeRows{rows: s.conn.driver.tables[s.conn.table]}, nil
+}
+
+func (r *fakeRows) Columns() []string {
+	if len(r.rows) == 0 {
+		return nil
+	}
+	columns := make([]string, len(r.rows[0]))
+	for i := range columns {
+		columns[i] = "c" + string(rune('0'+i))
+	}
+	return columns
+}
+
+func (r *fakeRows) Close() error {
+	return nil
+}
+
+func (r *fakeRows) Next(dest []driver.Value) error {
+	if r.next >= len(r.rows) {
+		return io.EOF
+	}
+	copy(dest, r.rows[r.next])
+	r.next++
+	return nil
+}
+
+func openFakeDB(t *testing.T) *sql.DB {
+	db, err := sql.Open("wrapfake", t.Name())
+	assert.NoError(t, err)
+	t.Cleanup(func() {
+		db.Close()
+		fake.mu.Lock()
+		delete(fake.tables, t.Name())
+		fake.mu.Unlock()
+	})
+	return db
+}
+
+func TestPtr_SQL(t *testing.T) {
+	db := openFakeDB(t)
+
+	name := "test"
+	count := 42
+	_, err := db.Exec("INSERT", wrap.NewPtr(&name), wrap.NewPtr(&count))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT", wrap.NewNilPtr[string](), wrap.NewNilPtr[int]())
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var names []wrap.Ptr[string]
+	var counts []wrap.Ptr[int]
+	for rows.Next() {
+		var n wrap.Ptr[string]
+		var c wrap.Ptr[int]
+		assert.NoError(t, rows.Scan(&n, &c))
+		names = append(names, n)
+		counts = append(counts, c)
+	}
+	assert.NoError(t, rows.Err())
+
+	assert.Len(t, names, 2)
+	val, ok := names[0].GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, "test", val)
+	num, ok := counts[0].GetValue()
+	assert.True(t, ok)
+	assert.Equal(t, 42, num)
+	assert.True(t, names[1].IsNil())
+	assert.True(t, counts[1].IsNil())
+}
+
+func TestPtr_Scan(t *testing.T) {
+	var ptr wrap.Ptr[string]
+	assert.NoError(t, ptr.Scan([]byte("bytes")))
+	val, _ := ptr.GetValue()
+	assert.Equal(t, "bytes", val)
+
+	var num wrap.Ptr[int]
+	assert.Error(t, num.Scan("not a number"))
+}
+
+func TestSlice_SQL(t *testing.T) {
+	db := openFakeDB(t)
+
+	_, err := db.Exec("INSERT", wrap.NewSlice([]string{"a", "b"}))
+	assert.NoError(t, err)
+	_, err = db.Exec("INSERT", wrap.NewSlice[string](nil))
+	assert.NoError(t, err)
+
+	rows, err := db.Query("SELECT")
+	assert.NoError(t, err)
+	defer rows.Close()
+
+	var scanned []wrap.Slice[string]
+	for rows.Next() {
+		var s wrap.Slice[string]
+		assert.NoError(t, rows.Scan(&s))
+		scanned = append(scanned, s)
+	}
+
+	assert.Len(t, scanned, 2)
+	assert.Equal(t, []string{"a", "b"}, scanned[0].Unwrap())
+	assert.Nil(t, scanned[1].Unwrap())
+
+	var s wrap.Slice[int]
+	assert.NoError(t, s.Scan(`[1,2]`))
+	assert.Equal(t, []int{1, 2}, s.Unwrap())
+	assert.Error(t, s.Scan(42))
+}
+
+func TestMap_SQL(t *testing.T) {
+	db := openFakeDB(t)
+
+	_, err := db.Exec("INSERT", wrap.NewMap(map[string]int{"a": 1}))
+	assert.NoError(t, err)
+
+	var m wrap.Map[string, int]
+	err = db.QueryRow("SELECT").Scan(&m)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int{"a": 1}, m.Unwrap())
+
+	assert.NoError(t, m.Scan(`{"b":2}`))
+	assert.Equal(t, map[string]int{"b": 2}, m.Unwrap())
+
+	assert.NoError(t, m.Scan(nil))
+	assert.Nil(t, m.Unwrap())
+}
@@ -0,0 +1,218 @@
+package wrap
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// SyncMap is a concurrency-safe wrapper for a map with keys of type K and values of type V.
+// The zero value is an empty map ready to use. A SyncMap must not be copied after first use.
+type SyncMap[K comparable, V any] struct {
+	mu sync.RWMutex
+	m  Map[K, V]
+}
+
+// NewSyncMap creates a new SyncMap instance with the provided initial map.
+// The SyncMap takes ownership of the map, which must not be accessed directly afterwards.
+func NewSyncMap[K comparable, V any](m map[K]V) *SyncMap[K, V] {
+	return &SyncMap[K, V]{
+		m: NewMap(m),
+	}
+}
+
+// init lazily allocates the underlying map. The caller must hold the write lock.
+func (m *SyncMap[K, V]) init() {
+	if m.m.X == nil {
+		m.m.X = make(map[K]V)
+	}
+}
+
+// Get retrieves the value associated with the specified key and a boolean indicating if the key exists.
+func (m *SyncMap[K, V]) Get(key K) (V, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Get(key)
+}
+
+// Set adds or updates the value for the specified key and returns the SyncMap instance.
+func (m *SyncMap[K, V]) Set(key K, value V) *SyncMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.init()
+	m.m.Set(key, value)
+	return m
+}
+
+// Delete removes the key-value pair associated with the specified key and returns the SyncMap instance.
+func (m *SyncMap[K, V]) Delete(key K) *SyncMap[K, V] {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Delete(key)
+	return m
+}
+
+// Contains checks if the specified key exists in the map.
+func (m *SyncMap[K, V]) Contains(key K) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Contains(key)
+}
+
+// Keys returns a slice of all keys in the map.
+func (m *SyncMap[K, V]) Keys() []K {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Keys()
+}
+
+// Values returns a slice of all values in the map.
+func (m *SyncMap[K, V]) Values() Slice[V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Values()
+}
+
+// Find returns the key of the first value that satisfies the provided comparison function, or zero value and false if not found.
+// The comparison function runs on a snapshot, without holding the lock.
+func (m *SyncMap[K, V]) Find(compare func(V) bool) (K, bool) {
+	snapshot := m.Snapshot()
+	return snapshot.Find(compare)
+}
+
+// Len returns the number of key-value pairs in the map.
+func (m *SyncMap[K, V]) Len() int {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.Len()
+}
+
+// IsEmpty returns true if the map is empty, otherwise false.
+func (m *SyncMap[K, V]) IsEmpty() bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.m.IsEmpty()
+}
+
+// Clear removes all key-value pairs from the map.
+func (m *SyncMap[K, V]) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m.Clear()
+}
+
+// GetOrSet returns the existing value for the key if present. Otherwise, it stores and returns the given value.
+// The boolean result is true if the value was loaded, false if stored.
+func (m *SyncMap[K, V]) GetOrSet(key K, value V) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if existing, ok := m.m.Get(key); ok {
+		return existing, true
+	}
+	m.init()
+	m.m.Set(key, value)
+	return value, false
+}
+
+// GetAndDelete removes the value for the key, returning the previous value if any.
+func (m *SyncMap[K, V]) GetAndDelete(key K) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	value, ok := m.m.Get(key)
+	if ok {
+		m.m.Delete(key)
+	}
+	return value, ok
+}
+
+// CompareAndSwap swaps the old and new values for the key if the value stored in the map is equal to old.
+// Values are compared with ==, so it panics if V is not comparable.
+func (m *SyncMap[K, V]) CompareAndSwap(key K, old, new V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.m.Get(key)
+	if !ok || any(current) != any(old) {
+		return false
+	}
+	m.m.Set(key, new)
+	return true
+}
+
+// CompareAndDelete deletes the entry for the key if its value is equal to old.
+// Values are compared with ==, so it panics if V is not comparable.
+func (m *SyncMap[K, V]) CompareAndDelete(key K, old V) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.m.Get(key)
+	if !ok || any(current) != any(old) {
+		return false
+	}
+	m.m.Delete(key)
+	return true
+}
+
+// Update atomically replaces the value for the key with the result of the provided function.
+// The function receives the current value and whether it exists; if it returns false the key is deleted.
+// The returned values are the new value and whether the key is present after the update.
+func (m *SyncMap[K, V]) Update(key K, update func(V, bool) (V, bool)) (V, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	current, ok := m.m.Get(key)
+	value, keep := update(current, ok)
+	if !keep {
+		m.m.Delete(key)
+		var zero V
+		return zero, false
+	}
+	m.init()
+	m.m.Set(key, value)
+	return value, true
+}
+
+// Snapshot returns a Map holding a copy of the current key-value pairs.
+func (m *SyncMap[K, V]) Snapshot() Map[K, V] {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	copied := make(map[K]V, len(m.m.X))
+	for key, value := range m.m.X {
+		copied[key] = value
+	}
+	return NewMap(copied)
+}
+
+// Range calls fn for each key-value pair of a snapshot of the map, stopping if fn returns false.
+// No lock is held while fn runs, so fn may safely call other SyncMap methods.
+func (m *SyncMap[K, V]) Range(fn func(K, V) bool) {
+	snapshot := m.Snapshot()
+	for key, value := range snapshot.X {
+		if !fn(key, value) {
+			return
+		}
+	}
+}
+
+// UnmarshalJSON unmarshals JSON data into the SyncMap. It expects a JSON object representation.
+func (m *SyncMap[K, V]) UnmarshalJSON(data []byte) error {
+	var decoded Map[K, V]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.m = decoded
+	return nil
+}
+
+// MarshalJSON marshals the SyncMap into JSON. It produces a JSON object representation.
+func (m *SyncMap[K, V]) MarshalJSON() ([]byte, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return json.Marshal(m.m.X)
+}
+
+// cloneReflect returns a deep copy of the SyncMap for DeepCopy, taken under the read lock.
+func (m *SyncMap[K, V]) cloneReflect(c *cloneState) reflect.Value {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return reflect.ValueOf(&SyncMap[K, V]{m: cloneValue(c, m.m)}).Elem()
+}
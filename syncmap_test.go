@@ -0,0 +1,125 @@
+package wrap
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncMap_SetGetDelete(t *testing.T) {
+	var m SyncMap[string, int]
+	assert.True(t, m.IsEmpty())
+
+	m.Set("a", 1).Set("b", 2)
+	value, ok := m.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, 2, m.Len())
+	assert.ElementsMatch(t, []string{"a", "b"}, m.Keys())
+	values := m.Values()
+	assert.ElementsMatch(t, []int{1, 2}, values.Unwrap())
+
+	m.Delete("a")
+	assert.False(t, m.Contains("a"))
+
+	m.Clear()
+	assert.Equal(t, 0, m.Len())
+}
+
+func TestSyncMap_GetOrSet(t *testing.T) {
+	m := NewSyncMap(map[string]int{"a": 1})
+
+	value, loaded := m.GetOrSet("a", 10)
+	assert.True(t, loaded)
+	assert.Equal(t, 1, value)
+
+	value, loaded = m.GetOrSet("b", 20)
+	assert.False(t, loaded)
+	assert.Equal(t, 20, value)
+}
+
+func TestSyncMap_CompareAndSwap(t *testing.T) {
+	m := NewSyncMap(map[string]int{"a": 1})
+
+	assert.False(t, m.CompareAndSwap("a", 2, 3))
+	assert.True(t, m.CompareAndSwap("a", 1, 3))
+	assert.False(t, m.CompareAndSwap("missing", 0, 1))
+
+	value, _ := m.Get("a")
+	assert.Equal(t, 3, value)
+
+	assert.False(t, m.CompareAndDelete("a", 1))
+	assert.True(t, m.CompareAndDelete("a", 3))
+	assert.False(t, m.Contains("a"))
+}
+
+func TestSyncMap_Update(t *testing.T) {
+	m := NewSyncMap(map[string]int{})
+
+	increment := func(v int, ok bool) (int, bool) { return v + 1, true }
+	value, ok := m.Update("count", increment)
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+
+	m.Update("count", increment)
+	value, _ = m.Get("count")
+	assert.Equal(t, 2, value)
+
+	_, ok = m.Update("count", func(v int, ok bool) (int, bool) { return 0, false })
+	assert.False(t, ok)
+	assert.False(t, m.Contains("count"))
+}
+
+func TestSyncMap_RangeWithoutLock(t *testing.T) {
+	m := NewSyncMap(map[string]int{"a": 1, "b": 2})
+
+	visited := 0
+	m.Range(func(key string, value int) bool {
+		// Mutating from the callback must not deadlock.
+		m.Set(key+key, value)
+		visited++
+		return true
+	})
+	assert.Equal(t, 2, visited)
+	assert.Equal(t, 4, m.Len())
+
+	key, ok := m.Find(func(v int) bool { return v == 2 })
+	assert.True(t, ok)
+	assert.Contains(t, []string{"b", "bb"}, key)
+}
+
+func TestSyncMap_Concurrent(t *testing.T) {
+	m := NewSyncMap(map[int]int{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			m.Set(i, i)
+			m.Update(-1, func(v int, ok bool) (int, bool) { return v + 1, true })
+			m.Keys()
+		}(i)
+	}
+	wg.Wait()
+
+	total, _ := m.Get(-1)
+	assert.Equal(t, 50, total)
+	assert.Equal(t, 51, m.Len())
+}
+
+func TestSyncMap_JSON(t *testing.T) {
+	m := NewSyncMap(map[string]int{"a": 1, "b": 2})
+	data, err := json.Marshal(m)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":1,"b":2}`, string(data))
+
+	var decoded SyncMap[string, int]
+	err = json.Unmarshal([]byte(`{"x":10}`), &decoded)
+	assert.NoError(t, err)
+	value, ok := decoded.Get("x")
+	assert.True(t, ok)
+	assert.Equal(t, 10, value)
+}
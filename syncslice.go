@@ -0,0 +1,239 @@
+package wrap
+
+import (
+	"encoding/json"
+	"reflect"
+	"sync"
+)
+
+// SyncSlice is a concurrency-safe wrapper for a slice of type T.
+// The zero value is an empty slice ready to use. A SyncSlice must not be copied after first use.
+type SyncSlice[T any] struct {
+	mu sync.RWMutex
+	s  Slice[T]
+}
+
+// NewSyncSlice creates a new SyncSlice instance with the provided initial slice.
+// The SyncSlice takes ownership of the slice, which must not be accessed directly afterwards.
+func NewSyncSlice[T any](slice []T) *SyncSlice[T] {
+	return &SyncSlice[T]{
+		s: NewSlice(slice),
+	}
+}
+
+// Unwrap returns a copy of the underlying slice of type T.
+func (s *SyncSlice[T]) Unwrap() []T {
+	copied := s.Copy()
+	return copied.X
+}
+
+// ValueAt retrieves the value at the specified index and a boolean indicating success.
+func (s *SyncSlice[T]) ValueAt(index int) (T, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.ValueAt(index)
+}
+
+// SetValueAt sets the value at the specified index and returns whether the operation was successful.
+func (s *SyncSlice[T]) SetValueAt(index int, value T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.SetValueAt(index, value)
+}
+
+// Append adds one or more values to the end of the slice.
+func (s *SyncSlice[T]) Append(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Append(values...)
+}
+
+// Prepend adds one or more values to the beginning of the slice.
+func (s *SyncSlice[T]) Prepend(values ...T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Prepend(values...)
+}
+
+// Pop removes and returns the last value from the slice, or false if the slice is empty.
+func (s *SyncSlice[T]) Pop() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Pop()
+}
+
+// Shift removes and returns the first value from the slice, or false if the slice is empty.
+func (s *SyncSlice[T]) Shift() (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Shift()
+}
+
+// InsertAt inserts one or more values at the specified index and returns whether the operation was successful.
+func (s *SyncSlice[T]) InsertAt(index int, values ...T) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.InsertAt(index, values...)
+}
+
+// RemoveAt removes a specified number of elements starting from the index and returns the removed elements as a new Slice.
+func (s *SyncSlice[T]) RemoveAt(index int, count ...int) Slice[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.RemoveAt(index, count...)
+}
+
+// Remove deletes all elements that satisfy the provided comparison function and returns removed elements as a new Slice.
+// The comparison function runs while the lock is held and must not call other SyncSlice methods.
+func (s *SyncSlice[T]) Remove(compare func(T) bool) Slice[T] {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.s.Remove(compare)
+}
+
+// Length returns the number of elements in the slice.
+func (s *SyncSlice[T]) Length() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Length()
+}
+
+// Capacity returns the capacity of the underlying slice.
+func (s *SyncSlice[T]) Capacity() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Capacity()
+}
+
+// Clear removes all elements from the slice.
+func (s *SyncSlice[T]) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Clear()
+}
+
+// SetCapacity changes the capacity of the slice if the new capacity is greater than the current length.
+func (s *SyncSlice[T]) SetCapacity(cap int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.SetCapacity(cap)
+}
+
+// Crop reduces the slice to contain only elements up to the specified index.
+func (s *SyncSlice[T]) Crop(index int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s.Crop(index)
+}
+
+// Copy returns a new Slice that is a consistent snapshot of the current slice.
+func (s *SyncSlice[T]) Copy() Slice[T] {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.s.Copy()
+}
+
+// Compact returns a snapshot of the slice with consecutive duplicate elements removed, based on the provided comparison function.
+// The slice itself is left unchanged.
+func (s *SyncSlice[T]) Compact(compare func(a, b T) bool) Slice[T] {
+	snapshot := s.Copy()
+	return snapshot.Compact(compare)
+}
+
+// IndexOf returns the index of the first element that satisfies the provided equality function, or -1 if not found.
+// The equality function runs on a snapshot, without holding the lock.
+func (s *SyncSlice[T]) IndexOf(equals func(T) bool) (int, bool) {
+	snapshot := s.Copy()
+	return snapshot.IndexOf(equals)
+}
+
+// Find returns the first element that satisfies the provided predicate function, or a zero value if not found.
+// The predicate function runs on a snapshot, without holding the lock.
+func (s *SyncSlice[T]) Find(predicate func(T) bool) (T, bool) {
+	snapshot := s.Copy()
+	return snapshot.Find(predicate)
+}
+
+// Filter returns a new Slice containing only elements that satisfy the provided predicate function.
+// The predicate function runs on a snapshot, without holding the lock.
+func (s *SyncSlice[T]) Filter(predicate func(T) bool) Slice[T] {
+	snapshot := s.Copy()
+	return snapshot.Filter(predicate)
+}
+
+// Contains returns true if there is an element that satisfies the provided equality function.
+func (s *SyncSlice[T]) Contains(equals func(T) bool) bool {
+	_, exists := s.IndexOf(equals)
+	return exists
+}
+
+// PopIf atomically removes and returns the last value if it satisfies the provided predicate function.
+func (s *SyncSlice[T]) PopIf(predicate func(T) bool) (T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var zero T
+	last, ok := s.s.ValueAt(len(s.s.X) - 1)
+	if !ok || !predicate(last) {
+		return zero, false
+	}
+	return s.s.Pop()
+}
+
+// AppendIfAbsent atomically appends the value unless an element satisfies the provided equality function.
+// It returns true if the value was appended.
+func (s *SyncSlice[T]) AppendIfAbsent(value T, equals func(T) bool) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.s.Contains(equals) {
+		return false
+	}
+	s.s.Append(value)
+	return true
+}
+
+// SwapAt atomically swaps the elements at the two indexes and returns whether the operation was successful.
+func (s *SyncSlice[T]) SwapAt(i, j int) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	a, okA := s.s.ValueAt(i)
+	b, okB := s.s.ValueAt(j)
+	if !okA || !okB {
+		return false
+	}
+	s.s.X[i], s.s.X[j] = b, a
+	return true
+}
+
+// WithLock runs fn with exclusive access to the underlying Slice, so a batch of changes is applied atomically.
+// The Slice must not be retained after fn returns, and fn must not call other SyncSlice methods.
+func (s *SyncSlice[T]) WithLock(fn func(*Slice[T])) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	fn(&s.s)
+}
+
+// UnmarshalJSON unmarshals JSON data into the SyncSlice.
+func (s *SyncSlice[T]) UnmarshalJSON(data []byte) error {
+	var decoded Slice[T]
+	if err := decoded.UnmarshalJSON(data); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.s = decoded
+	return nil
+}
+
+// MarshalJSON marshals a consistent snapshot of the SyncSlice into JSON.
+func (s *SyncSlice[T]) MarshalJSON() ([]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return json.Marshal(s.s.X)
+}
+
+// cloneReflect returns a deep copy of the SyncSlice for DeepCopy, taken under the read lock.
+func (s *SyncSlice[T]) cloneReflect(c *cloneState) reflect.Value {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return reflect.ValueOf(&SyncSlice[T]{s: cloneValue(c, s.s)}).Elem()
+}
@@ -0,0 +1,127 @@
+package wrap
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncSlice_Basic(t *testing.T) {
+	var s SyncSlice[int]
+	s.Append(2, 3)
+	s.Prepend(1)
+	assert.Equal(t, []int{1, 2, 3}, s.Unwrap())
+	assert.Equal(t, 3, s.Length())
+
+	assert.True(t, s.InsertAt(1, 10))
+	assert.Equal(t, []int{1, 10, 2, 3}, s.Unwrap())
+
+	removed := s.RemoveAt(1)
+	assert.Equal(t, []int{10}, removed.Unwrap())
+
+	value, ok := s.Pop()
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+
+	value, ok = s.Shift()
+	assert.True(t, ok)
+	assert.Equal(t, 1, value)
+	assert.Equal(t, []int{2}, s.Unwrap())
+
+	s.Clear()
+	_, ok = s.Pop()
+	assert.False(t, ok)
+}
+
+func TestSyncSlice_UnwrapIsCopy(t *testing.T) {
+	s := NewSyncSlice([]int{1, 2, 3})
+	unwrapped := s.Unwrap()
+	unwrapped[0] = 100
+
+	value, _ := s.ValueAt(0)
+	assert.Equal(t, 1, value)
+}
+
+func TestSyncSlice_PopIf(t *testing.T) {
+	s := NewSyncSlice([]int{1, 2, 3})
+
+	_, ok := s.PopIf(func(v int) bool { return v > 5 })
+	assert.False(t, ok)
+
+	value, ok := s.PopIf(func(v int) bool { return v == 3 })
+	assert.True(t, ok)
+	assert.Equal(t, 3, value)
+	assert.Equal(t, []int{1, 2}, s.Unwrap())
+
+	empty := NewSyncSlice([]int{})
+	_, ok = empty.PopIf(func(int) bool { return true })
+	assert.False(t, ok)
+}
+
+func TestSyncSlice_AppendIfAbsent(t *testing.T) {
+	s := NewSyncSlice([]string{"a"})
+
+	assert.False(t, s.AppendIfAbsent("a", func(v string) bool { return v == "a" }))
+	assert.True(t, s.AppendIfAbsent("b", func(v string) bool { return v == "b" }))
+	assert.Equal(t, []string{"a", "b"}, s.Unwrap())
+}
+
+func TestSyncSlice_SwapAt(t *testing.T) {
+	tests := []struct {
+		i, j     int
+		expected []int
+		ok       bool
+	}{
+		{0, 2, []int{3, 2, 1}, true},
+		{1, 1, []int{1, 2, 3}, true},
+		{0, 3, []int{1, 2, 3}, false},  // out of bounds
+		{-1, 0, []int{1, 2, 3}, false}, // negative index
+	}
+
+	for _, tt := range tests {
+		s := NewSyncSlice([]int{1, 2, 3})
+		assert.Equal(t, tt.ok, s.SwapAt(tt.i, tt.j))
+		assert.Equal(t, tt.expected, s.Unwrap())
+	}
+}
+
+func TestSyncSlice_WithLock(t *testing.T) {
+	s := NewSyncSlice([]int{1, 2, 3})
+	s.WithLock(func(inner *Slice[int]) {
+		inner.Append(4)
+		inner.RemoveAt(0)
+	})
+	assert.Equal(t, []int{2, 3, 4}, s.Unwrap())
+}
+
+func TestSyncSlice_Concurrent(t *testing.T) {
+	s := NewSyncSlice([]int{})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			s.AppendIfAbsent(i%10, func(v int) bool { return v == i%10 })
+			s.Filter(func(v int) bool { return v > 5 })
+			_, _ = json.Marshal(s)
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, 10, s.Length())
+}
+
+func TestSyncSlice_JSON(t *testing.T) {
+	s := NewSyncSlice([]int{1, 2, 3})
+	data, err := json.Marshal(s)
+	assert.NoError(t, err)
+	assert.Equal(t, `[1,2,3]`, string(data))
+
+	var decoded SyncSlice[int]
+	err = json.Unmarshal([]byte(`[4,5]`), &decoded)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 5}, decoded.Unwrap())
+}
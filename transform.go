@@ -0,0 +1,93 @@
+package wrap
+
+// MapSlice returns a new Slice holding the result of the provided function for each value of s.
+func MapSlice[T, U any](s Slice[T], transform func(T) U) Slice[U] {
+	mapped := make([]U, 0, len(s.X))
+	for _, v := range s.X {
+		mapped = append(mapped, transform(v))
+	}
+	return NewSlice(mapped)
+}
+
+// FlatMap returns a new Slice holding, in order, the values of every Slice produced by the provided function.
+func FlatMap[T, U any](s Slice[T], transform func(T) Slice[U]) Slice[U] {
+	flattened := make([]U, 0, len(s.X))
+	for _, v := range s.X {
+		flattened = append(flattened, transform(v).X...)
+	}
+	return NewSlice(flattened)
+}
+
+// Reduce combines the values of s into an accumulator, starting from initial.
+func Reduce[T, A any](s Slice[T], initial A, combine func(A, T) A) A {
+	accumulator := initial
+	for _, v := range s.X {
+		accumulator = combine(accumulator, v)
+	}
+	return accumulator
+}
+
+// GroupBy returns a Map from each key produced by the provided function to the values of s that produced it, in order.
+func GroupBy[T any, K comparable](s Slice[T], key func(T) K) Map[K, Slice[T]] {
+	groups := make(map[K]Slice[T])
+	for _, v := range s.X {
+		k := key(v)
+		group := groups[k]
+		group.Append(v)
+		groups[k] = group
+	}
+	return NewMap(groups)
+}
+
+// KeyBy returns a Map from each key produced by the provided function to its value. Later values overwrite earlier ones with the same key.
+func KeyBy[T any, K comparable](s Slice[T], key func(T) K) Map[K, T] {
+	keyed := make(map[K]T, len(s.X))
+	for _, v := range s.X {
+		keyed[key(v)] = v
+	}
+	return NewMap(keyed)
+}
+
+// Associate returns a Map holding the key-value pair produced by the provided function for each value of s.
+// Later pairs overwrite earlier ones with the same key.
+func Associate[T any, K comparable, V any](s Slice[T], pair func(T) (K, V)) Map[K, V] {
+	associated := make(map[K]V, len(s.X))
+	for _, v := range s.X {
+		key, value := pair(v)
+		associated[key] = value
+	}
+	return NewMap(associated)
+}
+
+// Partition splits s into the values that satisfy the provided predicate function and those that do not, preserving order.
+func Partition[T any](s Slice[T], predicate func(T) bool) (Slice[T], Slice[T]) {
+	matched := make([]T, 0, len(s.X))
+	unmatched := make([]T, 0, len(s.X))
+	for _, v := range s.X {
+		if predicate(v) {
+			matched = append(matched, v)
+		} else {
+			unmatched = append(unmatched, v)
+		}
+	}
+	return NewSlice(matched), NewSlice(unmatched)
+}
+
+// MapValues returns a new Map with the same keys as m and values transformed by the provided function.
+func MapValues[K comparable, V, U any](m Map[K, V], transform func(V) U) Map[K, U] {
+	mapped := make(map[K]U, len(m.X))
+	for key, value := range m.X {
+		mapped[key] = transform(value)
+	}
+	return NewMap(mapped)
+}
+
+// MapKeys returns a new Map with the same values as m and keys transformed by the provided function.
+// If several keys map to the same new key, which value is kept is unspecified.
+func MapKeys[K comparable, V any, J comparable](m Map[K, V], transform func(K) J) Map[J, V] {
+	mapped := make(map[J]V, len(m.X))
+	for key, value := range m.X {
+		mapped[transform(key)] = value
+	}
+	return NewMap(mapped)
+}
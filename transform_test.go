@@ -0,0 +1,88 @@
+package wrap
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type transformUser struct {
+	Name string
+	Team string
+	Age  int
+}
+
+var transformUsers = NewSlice([]transformUser{
+	{Name: "ann", Team: "red", Age: 30},
+	{Name: "bob", Team: "blue", Age: 25},
+	{Name: "cid", Team: "red", Age: 41},
+})
+
+func TestMapSlice(t *testing.T) {
+	names := MapSlice(transformUsers, func(u transformUser) string { return u.Name })
+	assert.Equal(t, []string{"ann", "bob", "cid"}, names.Unwrap())
+
+	upper := names.Filter(func(n string) bool { return n != "bob" })
+	assert.Equal(t, []string{"ann", "cid"}, upper.Unwrap())
+
+	empty := MapSlice(NewSlice([]int{}), strconv.Itoa)
+	assert.Equal(t, []string{}, empty.Unwrap())
+}
+
+func TestFlatMap(t *testing.T) {
+	words := NewSlice([]string{"a b", "c"})
+	flattened := FlatMap(words, func(s string) Slice[string] { return NewSlice(strings.Fields(s)) })
+	assert.Equal(t, []string{"a", "b", "c"}, flattened.Unwrap())
+}
+
+func TestReduce(t *testing.T) {
+	total := Reduce(transformUsers, 0, func(sum int, u transformUser) int { return sum + u.Age })
+	assert.Equal(t, 96, total)
+}
+
+func TestGroupBy(t *testing.T) {
+	teams := GroupBy(transformUsers, func(u transformUser) string { return u.Team })
+	assert.Equal(t, 2, teams.Len())
+
+	red, ok := teams.Get("red")
+	assert.True(t, ok)
+	names := MapSlice(red, func(u transformUser) string { return u.Name })
+	assert.Equal(t, []string{"ann", "cid"}, names.Unwrap())
+}
+
+func TestKeyBy(t *testing.T) {
+	byName := KeyBy(transformUsers, func(u transformUser) string { return u.Name })
+	bob, ok := byName.Get("bob")
+	assert.True(t, ok)
+	assert.Equal(t, 25, bob.Age)
+
+	byTeam := KeyBy(transformUsers, func(u transformUser) string { return u.Team })
+	red, _ := byTeam.Get("red")
+	assert.Equal(t, "cid", red.Name, "Later values should overwrite earlier ones")
+}
+
+func TestAssociate(t *testing.T) {
+	ages := Associate(transformUsers, func(u transformUser) (string, int) { return u.Name, u.Age })
+	assert.Equal(t, map[string]int{"ann": 30, "bob": 25, "cid": 41}, ages.Unwrap())
+}
+
+func TestPartition(t *testing.T) {
+	older, younger := Partition(transformUsers, func(u transformUser) bool { return u.Age >= 30 })
+	assert.Equal(t, 2, older.Length())
+	assert.Equal(t, 1, younger.Length())
+	first, _ := younger.ValueAt(0)
+	assert.Equal(t, "bob", first.Name)
+}
+
+func TestMapValuesAndKeys(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1, "b": 2})
+
+	strs := MapValues(m, strconv.Itoa)
+	assert.Equal(t, map[string]string{"a": "1", "b": "2"}, strs.Unwrap())
+
+	upper := MapKeys(m, strings.ToUpper)
+	assert.Equal(t, map[string]int{"A": 1, "B": 2}, upper.Unwrap())
+	assert.True(t, upper.Contains("A"))
+}
@@ -0,0 +1,183 @@
+package wrap
+
+import (
+	"errors"
+)
+
+// ErrTxDone is returned when changing, committing or rolling back a transaction that was already committed or rolled back.
+var ErrTxDone = errors.New("wrap: transaction has already been committed or rolled back")
+
+// ErrInvalidSavepoint is returned when rolling back to a savepoint that was rolled back, that belongs to another
+// transaction or that is the zero value.
+var ErrInvalidSavepoint = errors.New("wrap: invalid savepoint")
+
+// Savepoint marks a position within a transaction that can be rolled back to with RollbackTo.
+type Savepoint struct {
+	owner    *tx
+	position int
+	serial   uint64
+}
+
+// txStep is a reversible change recorded in the undo log of a transaction. serial identifies the change
+// within the transaction, so that savepoints can tell if the steps they follow were rolled back.
+type txStep struct {
+	undo   func()
+	redo   func()
+	serial uint64
+}
+
+// tx is the undo log shared by MapTx and SliceTx. Changes are applied immediately and undone in
+// reverse order on rollback, so a transaction costs memory proportional to its changes only.
+type tx struct {
+	steps   []txStep
+	serial  uint64
+	done    bool
+	history *History
+}
+
+// newTx creates a new transaction recording its changes into the first provided History, if any.
+func newTx(history []*History) tx {
+	t := tx{}
+	if len(history) > 0 {
+		t.history = history[0]
+	}
+	return t
+}
+
+// record appends a change to the undo log.
+func (t *tx) record(undo, redo func()) {
+	t.serial++
+	t.steps = append(t.steps, txStep{undo: undo, redo: redo, serial: t.serial})
+}
+
+// check returns ErrTxDone if the transaction is finished.
+func (t *tx) check() error {
+	if t.done {
+		return ErrTxDone
+	}
+	return nil
+}
+
+// Commit keeps the changes of the transaction and, if the transaction was started with a History, records them
+// so they can be undone later.
+func (t *tx) Commit() error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	t.done = true
+	if t.history != nil && len(t.steps) > 0 {
+		t.history.push(t.steps)
+	}
+	t.steps = nil
+	return nil
+}
+
+// Rollback undoes all the changes of the transaction, in reverse order.
+func (t *tx) Rollback() error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	t.undoTo(0)
+	t.done = true
+	return nil
+}
+
+// Savepoint returns a savepoint marking the current state of the transaction. Savepoints can be nested.
+func (t *tx) Savepoint() Savepoint {
+	return Savepoint{owner: t, position: len(t.steps), serial: t.lastSerial(len(t.steps))}
+}
+
+// RollbackTo undoes the changes made since the savepoint, which remains valid. Savepoints taken after it become invalid.
+func (t *tx) RollbackTo(savepoint Savepoint) error {
+	if err := t.check(); err != nil {
+		return err
+	}
+	if savepoint.owner != t || savepoint.position > len(t.steps) || savepoint.serial != t.lastSerial(savepoint.position) {
+		return ErrInvalidSavepoint
+	}
+	t.undoTo(savepoint.position)
+	return nil
+}
+
+// lastSerial returns the serial of the last step before the provided position of the undo log, or zero.
+func (t *tx) lastSerial(position int) uint64 {
+	if position == 0 {
+		return 0
+	}
+	return t.steps[position-1].serial
+}
+
+// undoTo undoes the changes recorded after the provided position of the undo log.
+func (t *tx) undoTo(position int) {
+	for i := len(t.steps) - 1; i >= position; i-- {
+		t.steps[i].undo()
+		t.steps[i] = txStep{}
+	}
+	t.steps = t.steps[:position]
+}
+
+// History records committed transactions so they can be undone and redone, like the history of an editor.
+// A History must only be used with transactions on the same wrapper, and not while one of them is in progress.
+type History struct {
+	depth int
+	undo  [][]txStep
+	redo  [][]txStep
+}
+
+// NewHistory creates a new History keeping at most depth transactions; older ones are dropped.
+// A depth less than or equal to zero keeps all of them.
+func NewHistory(depth int) *History {
+	return &History{depth: depth}
+}
+
+// Undo undoes the most recent committed transaction and returns false if there is nothing to undo.
+func (h *History) Undo() bool {
+	if len(h.undo) == 0 {
+		return false
+	}
+	steps := h.undo[len(h.undo)-1]
+	h.undo = h.undo[:len(h.undo)-1]
+	for i := len(steps) - 1; i >= 0; i-- {
+		steps[i].undo()
+	}
+	h.redo = append(h.redo, steps)
+	return true
+}
+
+// Redo reapplies the most recently undone transaction and returns false if there is nothing to redo.
+func (h *History) Redo() bool {
+	if len(h.redo) == 0 {
+		return false
+	}
+	steps := h.redo[len(h.redo)-1]
+	h.redo = h.redo[:len(h.redo)-1]
+	for _, step := range steps {
+		step.redo()
+	}
+	h.undo = append(h.undo, steps)
+	return true
+}
+
+// CanUndo returns true if there is a committed transaction to undo.
+func (h *History) CanUndo() bool {
+	return len(h.undo) > 0
+}
+
+// CanRedo returns true if there is an undone transaction to redo.
+func (h *History) CanRedo() bool {
+	return len(h.redo) > 0
+}
+
+// Clear forgets all recorded transactions.
+func (h *History) Clear() {
+	h.undo, h.redo = nil, nil
+}
+
+// push records a committed transaction, discarding the transactions that could be redone.
+func (h *History) push(steps []txStep) {
+	h.undo = append(h.undo, steps)
+	if h.depth > 0 && len(h.undo) > h.depth {
+		h.undo = append(h.undo[:0:0], h.undo[len(h.undo)-h.depth:]...)
+	}
+	h.redo = nil
+}
@@ -0,0 +1,150 @@
+package wrap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTx_Done(t *testing.T) {
+	m := NewMap(map[string]int{})
+
+	tx := m.Begin()
+	assert.NoError(t, tx.Commit())
+	assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+	assert.ErrorIs(t, tx.Rollback(), ErrTxDone)
+	assert.ErrorIs(t, tx.RollbackTo(Savepoint{}), ErrTxDone)
+	assert.ErrorIs(t, tx.Set("a", 1), ErrTxDone)
+	assert.ErrorIs(t, tx.Delete("a"), ErrTxDone)
+	assert.ErrorIs(t, tx.Clear(), ErrTxDone)
+	assert.Empty(t, m.X, "A finished transaction must not change the map")
+
+	tx = m.Begin()
+	assert.NoError(t, tx.Rollback())
+	assert.ErrorIs(t, tx.Commit(), ErrTxDone)
+
+	s := NewSlice([]int{1})
+	sliceTx := s.Begin()
+	assert.NoError(t, sliceTx.Commit())
+	assert.ErrorIs(t, sliceTx.Append(2), ErrTxDone)
+	_, err := sliceTx.InsertAt(0, 2)
+	assert.ErrorIs(t, err, ErrTxDone)
+	_, err = sliceTx.SetValueAt(0, 2)
+	assert.ErrorIs(t, err, ErrTxDone)
+	_, _, err = sliceTx.Pop()
+	assert.ErrorIs(t, err, ErrTxDone)
+	_, err = sliceTx.Remove(func(int) bool { return true })
+	assert.ErrorIs(t, err, ErrTxDone)
+	assert.ErrorIs(t, sliceTx.Crop(0), ErrTxDone)
+	assert.ErrorIs(t, sliceTx.Clear(), ErrTxDone)
+	assert.Equal(t, []int{1}, s.X)
+}
+
+func TestTx_Savepoints(t *testing.T) {
+	s := NewSlice([]int{1})
+	tx := s.Begin()
+
+	tx.Append(2)
+	outer := tx.Savepoint()
+	tx.Append(3)
+	inner := tx.Savepoint()
+	tx.Append(4)
+
+	assert.NoError(t, tx.RollbackTo(inner))
+	assert.Equal(t, []int{1, 2, 3}, s.X)
+
+	tx.Append(5)
+	assert.NoError(t, tx.RollbackTo(outer))
+	assert.Equal(t, []int{1, 2}, s.X)
+	assert.ErrorIs(t, tx.RollbackTo(inner), ErrInvalidSavepoint, "Savepoints after the rolled back one become invalid")
+
+	assert.NoError(t, tx.RollbackTo(outer), "The savepoint itself remains valid")
+	assert.NoError(t, tx.Rollback())
+	assert.Equal(t, []int{1}, s.X)
+}
+
+func TestTx_StaleSavepoints(t *testing.T) {
+	s := NewSlice([]int{})
+	tx := s.Begin()
+
+	start := tx.Savepoint()
+	tx.Append(1)
+	tx.Append(2)
+	stale := tx.Savepoint()
+	assert.NoError(t, tx.RollbackTo(start))
+
+	// New changes reach the position of the stale savepoint, which must still be rejected.
+	tx.Append(3)
+	tx.Append(4)
+	assert.ErrorIs(t, tx.RollbackTo(stale), ErrInvalidSavepoint)
+	assert.Equal(t, []int{3, 4}, s.X)
+
+	otherSlice := NewSlice([]int{})
+	other := otherSlice.Begin()
+	assert.ErrorIs(t, tx.RollbackTo(other.Savepoint()), ErrInvalidSavepoint, "Savepoints belong to their transaction")
+	assert.ErrorIs(t, tx.RollbackTo(Savepoint{}), ErrInvalidSavepoint)
+	assert.NoError(t, tx.RollbackTo(start))
+	assert.Empty(t, s.X)
+}
+
+func TestHistory_UndoRedo(t *testing.T) {
+	m := NewMap(map[string]int{"a": 1})
+	history := NewHistory(0)
+
+	tx := m.Begin(history)
+	tx.Set("a", 2)
+	tx.Set("b", 1)
+	assert.NoError(t, tx.Commit())
+	tx = m.Begin(history)
+	tx.Delete("a")
+	assert.NoError(t, tx.Commit())
+	assert.Equal(t, map[string]int{"b": 1}, m.X)
+
+	assert.True(t, history.Undo())
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, m.X)
+	assert.True(t, history.Undo())
+	assert.Equal(t, map[string]int{"a": 1}, m.X)
+	assert.False(t, history.Undo())
+
+	assert.True(t, history.Redo())
+	assert.Equal(t, map[string]int{"a": 2, "b": 1}, m.X)
+	assert.True(t, history.CanRedo())
+
+	// A new transaction discards what could be redone.
+	tx = m.Begin(history)
+	tx.Set("c", 3)
+	assert.NoError(t, tx.Commit())
+	assert.False(t, history.CanRedo())
+	assert.False(t, history.Redo())
+
+	history.Clear()
+	assert.False(t, history.CanUndo())
+}
+
+func TestHistory_Depth(t *testing.T) {
+	s := NewSlice([]int{})
+	history := NewHistory(2)
+	for i := 0; i < 5; i++ {
+		tx := s.Begin(history)
+		tx.Append(i)
+		assert.NoError(t, tx.Commit())
+	}
+
+	assert.True(t, history.Undo())
+	assert.True(t, history.Undo())
+	assert.False(t, history.Undo(), "Only the last 2 transactions are kept")
+	assert.Equal(t, []int{0, 1, 2}, s.X)
+}
+
+func TestHistory_IgnoresRollbacksAndEmptyTransactions(t *testing.T) {
+	m := NewMap(map[string]int{})
+	history := NewHistory(10)
+
+	tx := m.Begin(history)
+	tx.Set("a", 1)
+	assert.NoError(t, tx.Rollback())
+	tx = m.Begin(history)
+	tx.Delete("missing")
+	assert.NoError(t, tx.Commit())
+	assert.False(t, history.CanUndo())
+}
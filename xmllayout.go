@@ -0,0 +1,75 @@
+package wrap
+
+import "encoding/xml"
+
+// XMLMapLayout selects how the entries of an XMLMap are written when marshalling to XML.
+// encoding/xml does not pass struct tag options to custom marshalers, so the layout is chosen per field through
+// the layout type parameter of XMLMap. Implementations are usually empty structs.
+type XMLMapLayout interface {
+	// XMLEntryName returns the element name used for each entry.
+	XMLEntryName() string
+	// XMLKeyChildren reports whether entries are written as <entry><key>k</key><value>v</value></entry>
+	// rather than as <entry key="k">v</entry>.
+	XMLKeyChildren() bool
+}
+
+// XMLKeyAttr is the XMLMapLayout writing each entry as <entry key="k">v</entry>. Map uses it.
+type XMLKeyAttr struct{}
+
+// XMLEntryName returns "entry".
+func (XMLKeyAttr) XMLEntryName() string { return "entry" }
+
+// XMLKeyChildren returns false.
+func (XMLKeyAttr) XMLKeyChildren() bool { return false }
+
+// XMLKeyValueChildren is the XMLMapLayout writing each entry as <entry><key>k</key><value>v</value></entry>.
+type XMLKeyValueChildren struct{}
+
+// XMLEntryName returns "entry".
+func (XMLKeyValueChildren) XMLEntryName() string { return "entry" }
+
+// XMLKeyChildren returns true.
+func (XMLKeyValueChildren) XMLKeyChildren() bool { return true }
+
+// XMLSliceLayout selects how the values of an XMLSlice are written when marshalling to XML.
+// Implementations are usually empty structs, passed as the layout type parameter of XMLSlice.
+type XMLSliceLayout interface {
+	// XMLItemName returns the element name used for each value.
+	XMLItemName() string
+}
+
+// XMLMap is a Map whose XML entries are written using the layout L. Every other method and codec is the one of Map.
+// Unmarshalling accepts both entry layouts, whatever the element names.
+type XMLMap[K comparable, V any, L XMLMapLayout] struct {
+	Map[K, V]
+}
+
+// NewXMLMap creates a new XMLMap instance with the provided initial map, written using the layout L.
+func NewXMLMap[L XMLMapLayout, K comparable, V any](m map[K]V) XMLMap[K, V, L] {
+	return XMLMap[K, V, L]{Map: NewMap(m)}
+}
+
+// MarshalXML marshals the XMLMap into XML using the layout L. Entries are sorted by key, or by formatted key
+// when K is not an ordered type, so the output is deterministic. If the map is nil, no element is written.
+func (m XMLMap[K, V, L]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var layout L
+	return marshalXMLMap(e, start, m.X, layout)
+}
+
+// XMLSlice is a Slice whose XML values are written using the layout L. Every other method and codec is the one of Slice.
+// Unmarshalling accepts child elements of any name.
+type XMLSlice[T any, L XMLSliceLayout] struct {
+	Slice[T]
+}
+
+// NewXMLSlice creates a new XMLSlice instance with the provided initial slice, written using the layout L.
+func NewXMLSlice[L XMLSliceLayout, T any](slice []T) XMLSlice[T, L] {
+	return XMLSlice[T, L]{Slice: NewSlice(slice)}
+}
+
+// MarshalXML marshals the XMLSlice into XML, writing each value as a child element named by the layout L.
+// If the slice is nil, no element is written.
+func (s XMLSlice[T, L]) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	var layout L
+	return marshalXMLItems(e, start, s.X, layout.XMLItemName())
+}
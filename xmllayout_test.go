@@ -0,0 +1,75 @@
+package wrap
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type xmlTagLayout struct{}
+
+func (xmlTagLayout) XMLItemName() string { return "tag" }
+
+type xmlPropLayout struct{}
+
+func (xmlPropLayout) XMLEntryName() string { return "prop" }
+func (xmlPropLayout) XMLKeyChildren() bool { return true }
+
+func TestXMLMap_MarshalXML(t *testing.T) {
+	type Test struct {
+		XMLName  xml.Name                                 `xml:"test"`
+		Attrs    XMLMap[string, int, XMLKeyAttr]          `xml:"attrs"`
+		Children XMLMap[string, int, XMLKeyValueChildren] `xml:"children"`
+		Props    XMLMap[string, int, xmlPropLayout]       `xml:"props"`
+		Default  Map[string, int]                         `xml:"default"`
+		Missing  XMLMap[string, int, XMLKeyValueChildren] `xml:"missing"`
+	}
+
+	values := map[string]int{"b": 2, "a": 1}
+	test := Test{
+		Attrs:    NewXMLMap[XMLKeyAttr](values),
+		Children: NewXMLMap[XMLKeyValueChildren](values),
+		Props:    NewXMLMap[xmlPropLayout](map[string]int{"a": 1}),
+		Default:  NewMap(map[string]int{"a": 1}),
+	}
+	data, err := xml.Marshal(test)
+	assert.NoError(t, err)
+	assert.Equal(t, `<test>`+
+		`<attrs><entry key="a">1</entry><entry key="b">2</entry></attrs>`+
+		`<children><entry><key>a</key><value>1</value></entry><entry><key>b</key><value>2</value></entry></children>`+
+		`<props><prop><key>a</key><value>1</value></prop></props>`+
+		`<default><entry key="a">1</entry></default>`+
+		`</test>`, string(data))
+
+	var decoded Test
+	assert.NoError(t, xml.Unmarshal(data, &decoded))
+	assert.Equal(t, values, decoded.Attrs.X)
+	assert.Equal(t, values, decoded.Children.X)
+	assert.Equal(t, map[string]int{"a": 1}, decoded.Props.X)
+}
+
+func TestXMLSlice_MarshalXML(t *testing.T) {
+	type Test struct {
+		Tags  XMLSlice[int, xmlTagLayout] `xml:"tags"`
+		Items Slice[int]                  `xml:"items"`
+	}
+
+	data, err := xml.Marshal(Test{Tags: NewXMLSlice[xmlTagLayout]([]int{1, 2}), Items: NewSlice([]int{3})})
+	assert.NoError(t, err)
+	assert.Equal(t, "<Test><tags><tag>1</tag><tag>2</tag></tags><items><item>3</item></items></Test>", string(data))
+
+	var decoded Test
+	assert.NoError(t, xml.Unmarshal(data, &decoded))
+	assert.Equal(t, []int{1, 2}, decoded.Tags.X)
+}
+
+func TestXMLLayout_JSON(t *testing.T) {
+	tags := NewXMLSlice[xmlTagLayout]([]string{"a"})
+	props := NewXMLMap[xmlPropLayout](map[string]int{"a": 1})
+
+	data, err := json.Marshal(map[string]any{"tags": tags, "props": props})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"tags":["a"],"props":{"a":1}}`, string(data))
+}